@@ -157,3 +157,31 @@ func RunAppCommand(file string) *cli.Command {
 		},
 	}
 }
+
+func MockServerCommand(file string) *cli.Command {
+	var port int
+
+	return &cli.Command{
+		Name: "mock",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Aliases:     []string{"c"},
+				Value:       ".env",
+				Usage:       "Config file",
+				Destination: &file,
+			},
+			&cli.IntFlag{
+				Name:        "port",
+				Aliases:     []string{"p"},
+				Usage:       "Mock server port, default is APP_PORT",
+				Destination: &port,
+			},
+		},
+		Description: "mock [-c <config>] [--port <port>]",
+		Usage:       "Run mock server from swagger files",
+		Action: func(*cli.Context) error {
+			return tool.Mock(file).Run(port)
+		},
+	}
+}
@@ -37,77 +37,117 @@ func createPackage() *cli.Command {
 }
 
 func createMiddleware() *cli.Command {
+	force := false
+
 	return &cli.Command{
 		Name:        "middleware",
 		Aliases:     []string{"mid"},
-		Description: "bima create middleware <name>",
+		Description: "bima create middleware [--force] <name>",
 		Usage:       "Create new middleware",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "Overwrite existing middleware",
+				Destination: &force,
+			},
+		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.Args().First()
 			if name == "" {
-				fmt.Println("Usage: bima create middleware <name>")
+				fmt.Println("Usage: bima create middleware [--force] <name>")
 
 				return nil
 			}
 
-			return tool.Middleware(name).Create()
+			return tool.Middleware(name).Create(force)
 		},
 	}
 }
 
 func createDriver() *cli.Command {
+	force := false
+
 	return &cli.Command{
 		Name:        "driver",
 		Aliases:     []string{"dvr"},
-		Description: "bima create driver <name>",
+		Description: "bima create driver [--force] <name>",
 		Usage:       "Create new driver",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "Overwrite existing driver",
+				Destination: &force,
+			},
+		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.Args().First()
 			if name == "" {
-				fmt.Println("Usage: bima create driver <name>")
+				fmt.Println("Usage: bima create driver [--force] <name>")
 
 				return nil
 			}
 
-			return tool.Driver(name).Create()
+			return tool.Driver(name).Create(force)
 		},
 	}
 }
 
 func createAdapter() *cli.Command {
+	force := false
+
 	return &cli.Command{
 		Name:        "adapter",
 		Aliases:     []string{"adp"},
-		Description: "bima create adapter <name>",
+		Description: "bima create adapter [--force] <name>",
 		Usage:       "Create new adapter",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "Overwrite existing adapter",
+				Destination: &force,
+			},
+		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.Args().First()
 			if name == "" {
-				fmt.Println("Usage: bima create adapter <name>")
+				fmt.Println("Usage: bima create adapter [--force] <name>")
 
 				return nil
 			}
 
-			return tool.Adapter(name).Create()
+			return tool.Adapter(name).Create(force)
 		},
 	}
 }
 
 func createRoute() *cli.Command {
+	force := false
+
 	return &cli.Command{
 		Name:        "route",
 		Aliases:     []string{"rt"},
-		Description: "bima create route <name>",
+		Description: "bima create route [--force] <name>",
 		Usage:       "Create new route",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "Overwrite existing route",
+				Destination: &force,
+			},
+		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.Args().First()
 			if name == "" {
-				fmt.Println("Usage: bima create route <name>")
+				fmt.Println("Usage: bima create route [--force] <name>")
 
 				return nil
 			}
 
-			return tool.Route(name).Create()
+			return tool.Route(name).Create(force)
 		},
 	}
 }
@@ -0,0 +1,156 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/bimalabs/cli/bima"
+	"github.com/bimalabs/cli/tool"
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+func DocsCommand(file string) *cli.Command {
+	return &cli.Command{
+		Name:        "docs",
+		Aliases:     []string{"doc"},
+		Usage:       "Generate api documentation from swagger files",
+		Description: "docs <command>",
+		Subcommands: []*cli.Command{docsCollection(file), docsBuild(file), docsServe(file), docsRebuild()},
+	}
+}
+
+func docsCollection(file string) *cli.Command {
+	var output string
+
+	return &cli.Command{
+		Name: "collection",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Aliases:     []string{"c"},
+				Value:       ".env",
+				Usage:       "Config file",
+				Destination: &file,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Value:       "docs",
+				Usage:       "Output directory",
+				Destination: &output,
+			},
+		},
+		Aliases:     []string{"col"},
+		Description: "docs collection [-c <config>] [--output <dir>]",
+		Usage:       "Export postman collection and .http files",
+		Action: func(*cli.Context) error {
+			progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
+			progress.Suffix = " Exporting collection from swagger file(s)... "
+			progress.Start()
+			if err := tool.Docs(file).Collection(output); err != nil {
+				progress.Stop()
+				color.New(color.FgRed).Println("Error export collection")
+
+				return err
+			}
+
+			progress.Stop()
+			fmt.Printf("Collection exported to %s\n", output)
+
+			return nil
+		},
+	}
+}
+
+func docsBuild(file string) *cli.Command {
+	var format, output string
+
+	return &cli.Command{
+		Name: "build",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Aliases:     []string{"c"},
+				Value:       ".env",
+				Usage:       "Config file",
+				Destination: &file,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Aliases:     []string{"f"},
+				Value:       "yaml",
+				Usage:       "Output format, yaml or json",
+				Destination: &format,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "Output file, default is docs/openapi.<format>",
+				Destination: &output,
+			},
+		},
+		Description: "docs build [-c <config>] [--format yaml|json] [--output <file>]",
+		Usage:       "Merge module swaggers and routes into single openapi 3.1 document",
+		Action: func(*cli.Context) error {
+			if output == "" {
+				output = fmt.Sprintf("docs/openapi.%s", format)
+			}
+
+			progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
+			progress.Suffix = " Building openapi document... "
+			progress.Start()
+			if err := tool.Docs(file).Build(format, output); err != nil {
+				progress.Stop()
+				color.New(color.FgRed).Println("Error build openapi document")
+
+				return err
+			}
+
+			progress.Stop()
+			fmt.Printf("Openapi document written to %s\n", output)
+
+			return nil
+		},
+	}
+}
+
+func docsServe(file string) *cli.Command {
+	var port int
+
+	return &cli.Command{
+		Name: "serve",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Aliases:     []string{"c"},
+				Value:       ".env",
+				Usage:       "Config file",
+				Destination: &file,
+			},
+			&cli.IntFlag{
+				Name:        "port",
+				Aliases:     []string{"p"},
+				Value:       7171,
+				Usage:       "Documentation server port",
+				Destination: &port,
+			},
+		},
+		Description: "docs serve [-c <config>] [--port <port>]",
+		Usage:       "Serve api documentation from swagger files",
+		Action: func(*cli.Context) error {
+			return tool.Docs(file).Serve(port)
+		},
+	}
+}
+
+func docsRebuild() *cli.Command {
+	return &cli.Command{
+		Name:        "rebuild",
+		Description: "docs rebuild",
+		Usage:       "Rebuild swaggers/modules.json from registered modules and swagger files",
+		Action: func(*cli.Context) error {
+			return tool.Docs("").Rebuild()
+		},
+	}
+}
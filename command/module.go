@@ -13,34 +13,56 @@ func ModuleCommand(file string) *cli.Command {
 		Aliases:     []string{"mod"},
 		Usage:       "Create or remove module",
 		Description: "module <command>",
-		Subcommands: []*cli.Command{moduleAdd(file), removeModule()},
+		Subcommands: []*cli.Command{moduleAdd(file), moduleFromOpenapi(file), removeModule()},
 	}
 }
 
 func moduleAdd(file string) *cli.Command {
+	option := tool.ModuleOption{}
+
 	return &cli.Command{
-		Name: "add",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:        "config",
-				Aliases:     []string{"c"},
-				Value:       ".env",
-				Usage:       "Config file",
-				Destination: &file,
-			},
-		},
+		Name:        "add",
+		Flags:       moduleFlags(&file, &option),
 		Aliases:     []string{"new"},
-		Description: "module add <name> [-c <config>]",
+		Description: "module add <name> [-c <config>] [--prefix <prefix>] [--path <path>] [--connection <connection>] [--authorization] [--events] [--batch] [--csv] [--tenant-scoped]",
 		Usage:       "Create new module <name> use <config> file",
 		Action: func(ctx *cli.Context) error {
 			name := ctx.Args().First()
 			if name == "" {
-				fmt.Println("Usage: bima module add <name> [-c <config>]")
+				fmt.Println("Usage: bima module add <name> [-c <config>] [--prefix <prefix>] [--path <path>] [--connection <connection>] [--authorization] [--events] [--batch] [--csv] [--tenant-scoped]")
+
+				return nil
+			}
+
+			return tool.Module(name).Create(file, option)
+		},
+	}
+}
+
+func moduleFromOpenapi(file string) *cli.Command {
+	option := tool.ModuleOption{}
+	schemas := cli.StringSlice{}
+
+	return &cli.Command{
+		Name: "from-openapi",
+		Flags: append(moduleFlags(&file, &option), &cli.StringSliceFlag{
+			Name:        "schema",
+			Aliases:     []string{"s"},
+			Usage:       "Schema name to generate, can be repeated, default is asked for each schema",
+			Destination: &schemas,
+		}),
+		Aliases:     []string{"openapi"},
+		Description: "module from-openapi <spec> [-c <config>] [--schema <name>] [--prefix <prefix>] [--path <path>] [--connection <connection>] [--authorization] [--events] [--batch] [--csv] [--tenant-scoped]",
+		Usage:       "Create modules from schemas of openapi 2 or 3 <spec> file",
+		Action: func(ctx *cli.Context) error {
+			spec := ctx.Args().First()
+			if spec == "" {
+				fmt.Println("Usage: bima module from-openapi <spec> [-c <config>] [--schema <name>] [--prefix <prefix>] [--path <path>] [--connection <connection>] [--authorization] [--events] [--batch] [--csv] [--tenant-scoped]")
 
 				return nil
 			}
 
-			return tool.Module(name).Create(file)
+			return tool.Specification(spec).Create(file, schemas.Value(), option)
 		},
 	}
 }
@@ -63,3 +85,55 @@ func removeModule() *cli.Command {
 		},
 	}
 }
+
+func moduleFlags(file *string, option *tool.ModuleOption) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "config",
+			Aliases:     []string{"c"},
+			Value:       ".env",
+			Usage:       "Config file",
+			Destination: file,
+		},
+		&cli.StringFlag{
+			Name:        "prefix",
+			Usage:       "Api prefix, default is API_PREFIX",
+			Destination: &option.Prefix,
+		},
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "Api base path, default is plural form of module name",
+			Destination: &option.Path,
+		},
+		&cli.StringFlag{
+			Name:        "connection",
+			Usage:       "Database connection defined in DB_CONNECTIONS, default is primary database",
+			Destination: &option.Connection,
+		},
+		&cli.BoolFlag{
+			Name:        "authorization",
+			Usage:       "Generate role based authorization middleware",
+			Destination: &option.Authorization,
+		},
+		&cli.BoolFlag{
+			Name:        "events",
+			Usage:       "Publish <module>.created, <module>.updated and <module>.deleted events",
+			Destination: &option.Events,
+		},
+		&cli.BoolFlag{
+			Name:        "batch",
+			Usage:       "Generate batch create, update and delete endpoints",
+			Destination: &option.Batch,
+		},
+		&cli.BoolFlag{
+			Name:        "csv",
+			Usage:       "Generate csv export and import endpoints",
+			Destination: &option.Csv,
+		},
+		&cli.BoolFlag{
+			Name:        "tenant-scoped",
+			Usage:       "Scope module data by tenant from request metadata",
+			Destination: &option.Tenant,
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/bimalabs/cli/tool"
+	"github.com/urfave/cli/v2"
+)
+
+func RemoveCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "remove",
+		Aliases:     []string{"rm", "rem"},
+		Usage:       "Remove something created with bima",
+		Description: "bima remove <command>",
+		Subcommands: []*cli.Command{removeMiddleware(), removeRoute(), removeAdapter(), removeDriver()},
+	}
+}
+
+func removeMiddleware() *cli.Command {
+	dryRun := false
+
+	return &cli.Command{
+		Name:        "middleware",
+		Aliases:     []string{"mid"},
+		Description: "bima remove middleware [--dry-run] <name>",
+		Usage:       "Remove middleware",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "Show what would be removed without changing anything",
+				Destination: &dryRun,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().First()
+			if name == "" {
+				fmt.Println("Usage: bima remove middleware [--dry-run] <name>")
+
+				return nil
+			}
+
+			return tool.Middleware(name).Remove(dryRun)
+		},
+	}
+}
+
+func removeRoute() *cli.Command {
+	dryRun := false
+
+	return &cli.Command{
+		Name:        "route",
+		Aliases:     []string{"rt"},
+		Description: "bima remove route [--dry-run] <name>",
+		Usage:       "Remove route",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "Show what would be removed without changing anything",
+				Destination: &dryRun,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().First()
+			if name == "" {
+				fmt.Println("Usage: bima remove route [--dry-run] <name>")
+
+				return nil
+			}
+
+			return tool.Route(name).Remove(dryRun)
+		},
+	}
+}
+
+func removeAdapter() *cli.Command {
+	dryRun := false
+
+	return &cli.Command{
+		Name:        "adapter",
+		Aliases:     []string{"adp"},
+		Description: "bima remove adapter [--dry-run] <name>",
+		Usage:       "Remove adapter",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "Show what would be removed without changing anything",
+				Destination: &dryRun,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().First()
+			if name == "" {
+				fmt.Println("Usage: bima remove adapter [--dry-run] <name>")
+
+				return nil
+			}
+
+			return tool.Adapter(name).Remove(dryRun)
+		},
+	}
+}
+
+func removeDriver() *cli.Command {
+	dryRun := false
+
+	return &cli.Command{
+		Name:        "driver",
+		Aliases:     []string{"dvr"},
+		Description: "bima remove driver [--dry-run] <name>",
+		Usage:       "Remove driver",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "Show what would be removed without changing anything",
+				Destination: &dryRun,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().First()
+			if name == "" {
+				fmt.Println("Usage: bima remove driver [--dry-run] <name>")
+
+				return nil
+			}
+
+			return tool.Driver(name).Remove(dryRun)
+		},
+	}
+}
@@ -81,6 +81,7 @@ func GenerateProtobufCommand() *cli.Command {
 		Aliases:     []string{"gen", "genproto"},
 		Description: "generate",
 		Usage:       "Generate code from protobuf file(s)",
+		Subcommands: []*cli.Command{generateSdk()},
 		Action: func(*cli.Context) error {
 			progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
 			progress.Suffix = " Generating codes from protobuff file(s)... "
@@ -113,6 +114,48 @@ func GenerateProtobufCommand() *cli.Command {
 	}
 }
 
+func generateSdk() *cli.Command {
+	var lang, output string
+
+	return &cli.Command{
+		Name: "sdk",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "lang",
+				Aliases:     []string{"l"},
+				Value:       "ts",
+				Usage:       "Sdk language, only ts is supported",
+				Destination: &lang,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Value:       "sdk/ts",
+				Usage:       "Output directory",
+				Destination: &output,
+			},
+		},
+		Description: "generate sdk [--lang ts] [--output <dir>]",
+		Usage:       "Generate typed client sdk from swagger file(s)",
+		Action: func(*cli.Context) error {
+			progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
+			progress.Suffix = " Generating sdk from swagger file(s)... "
+			progress.Start()
+			if err := tool.Sdk(lang).Generate(output); err != nil {
+				progress.Stop()
+				color.New(color.FgRed).Println("Error generate sdk")
+
+				return err
+			}
+
+			progress.Stop()
+			fmt.Printf("Sdk generated in %s\n", output)
+
+			return nil
+		},
+	}
+}
+
 func CheckVersionCommand() *cli.Command {
 	return &cli.Command{
 		Name:        "version",
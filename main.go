@@ -17,13 +17,16 @@ func main() {
 		EnableBashCompletion: true,
 		Commands: []*cli.Command{
 			command.CreateCommand(),
+			command.RemoveCommand(),
 			command.ModuleCommand(file),
 			command.BuildAppCommand(),
 			command.RunAppCommand(file),
+			command.MockServerCommand(file),
 			command.DumpServiceContainerCommand(),
 			command.UpdateDependenciesCommand(),
 			command.CleanDependenciesCommand(),
 			command.GenerateProtobufCommand(),
+			command.DocsCommand(file),
 			command.MakesureToolchainInstalledCommand(),
 			command.CheckVersionCommand(),
 			command.UpgradeCliCommand(),
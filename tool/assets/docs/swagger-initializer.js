@@ -0,0 +1,16 @@
+window.onload = function () {
+    'use strict';
+
+    window.ui = SwaggerUIBundle({
+        url: 'openapi.json',
+        dom_id: '#swagger-ui',
+        deepLinking: true,
+        presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+        plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+        layout: 'StandaloneLayout'
+    });
+
+    new EventSource('events').onmessage = function () {
+        window.ui.specActions.download('openapi.json');
+    };
+};
@@ -0,0 +1,88 @@
+// Package queries is copied into project as queries/queries.go by bima module add, generated list endpoints only accept declared columns and operators
+package queries
+
+import (
+	"sort"
+	"strings"
+)
+
+type Column struct {
+	Operators  []string
+	Sortable   bool
+	Searchable bool
+}
+
+var operators = map[string]string{
+	"eq":   "= ?",
+	"ne":   "<> ?",
+	"gt":   "> ?",
+	"gte":  ">= ?",
+	"lt":   "< ?",
+	"lte":  "<= ?",
+	"like": "LIKE ?",
+	"in":   "IN ?",
+}
+
+// Allowed check column is declared with operator
+func Allowed(columns map[string]Column, column string, operator string) bool {
+	declared, ok := columns[column]
+	if !ok {
+		return false
+	}
+
+	if _, ok = operators[operator]; !ok {
+		return false
+	}
+
+	for _, v := range declared.Operators {
+		if v == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Condition convert <column>:<operator> filter to where clause, false when filter is not allowed
+func Condition(columns map[string]Column, filter string) (string, string, bool) {
+	parts := strings.SplitN(filter, ":", 2)
+	if len(parts) != 2 || !Allowed(columns, parts[0], parts[1]) {
+		return "", "", false
+	}
+
+	return parts[0] + " " + operators[parts[1]], parts[1], true
+}
+
+// Order convert <column> or -<column> to order clause, false when column is not sortable
+func Order(columns map[string]Column, value string) (string, bool) {
+	column := strings.TrimPrefix(value, "-")
+	if declared, ok := columns[column]; !ok || !declared.Sortable {
+		return "", false
+	}
+
+	if strings.HasPrefix(value, "-") {
+		return column + " DESC", true
+	}
+
+	return column + " ASC", true
+}
+
+// Search return where clause and values to search keyword on searchable columns
+func Search(columns map[string]Column, keyword string) (string, []interface{}) {
+	searchable := []string{}
+	for column, declared := range columns {
+		if declared.Searchable {
+			searchable = append(searchable, column)
+		}
+	}
+	sort.Strings(searchable)
+
+	conditions := make([]string, 0, len(searchable))
+	values := make([]interface{}, 0, len(searchable))
+	for _, column := range searchable {
+		conditions = append(conditions, column+" LIKE ?")
+		values = append(values, "%"+keyword+"%")
+	}
+
+	return strings.Join(conditions, " OR "), values
+}
@@ -0,0 +1,88 @@
+package queries
+
+import (
+	"reflect"
+	"testing"
+)
+
+var columns = map[string]Column{
+	"task":  {Operators: []string{"eq", "like"}, Sortable: true, Searchable: true},
+	"done":  {Operators: []string{"eq"}},
+	"owner": {Operators: []string{"eq", "drop"}, Searchable: true},
+}
+
+func TestCondition(t *testing.T) {
+	cases := []struct {
+		filter    string
+		condition string
+		operator  string
+		allowed   bool
+	}{
+		{filter: "task:eq", condition: "task = ?", operator: "eq", allowed: true},
+		{filter: "task:like", condition: "task LIKE ?", operator: "like", allowed: true},
+		{filter: "done:eq", condition: "done = ?", operator: "eq", allowed: true},
+		{filter: "done:like"},
+		{filter: "task:gt"},
+		{filter: "owner:drop"},
+		{filter: "task:= 1 OR 1"},
+		{filter: "unknown:eq"},
+		{filter: "1=1 OR task:eq"},
+		{filter: "task:"},
+		{filter: "task"},
+		{filter: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.filter, func(t *testing.T) {
+			condition, operator, allowed := Condition(columns, c.filter)
+			if condition != c.condition || operator != c.operator || allowed != c.allowed {
+				t.Errorf("expected (%q, %q, %t), got (%q, %q, %t)", c.condition, c.operator, c.allowed, condition, operator, allowed)
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	cases := []struct {
+		sort    string
+		order   string
+		allowed bool
+	}{
+		{sort: "task", order: "task ASC", allowed: true},
+		{sort: "-task", order: "task DESC", allowed: true},
+		{sort: "done"},
+		{sort: "unknown"},
+		{sort: "task; DROP TABLE todos"},
+		{sort: "--task"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.sort, func(t *testing.T) {
+			order, allowed := Order(columns, c.sort)
+			if order != c.order || allowed != c.allowed {
+				t.Errorf("expected (%q, %t), got (%q, %t)", c.order, c.allowed, order, allowed)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		name      string
+		columns   map[string]Column
+		condition string
+		values    []interface{}
+	}{
+		{name: "searchable columns", columns: columns, condition: "owner LIKE ? OR task LIKE ?", values: []interface{}{"%keyword%", "%keyword%"}},
+		{name: "no searchable column", columns: map[string]Column{"done": {Operators: []string{"eq"}}}, values: []interface{}{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			condition, values := Search(c.columns, "keyword")
+			if condition != c.condition || !reflect.DeepEqual(values, c.values) {
+				t.Errorf("expected (%q, %v), got (%q, %v)", c.condition, c.values, condition, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"golang.org/x/mod/modfile"
+)
+
+type (
+	clientTemplate struct {
+		Name    string
+		Package string
+		Service string
+		Methods []clientMethod
+	}
+
+	clientMethod struct {
+		Name       string
+		Request    string
+		Response   string
+		ById       bool
+		Idempotent bool
+	}
+)
+
+var (
+	serviceRegex = regexp.MustCompile(`(?m)^service\s+(\w+)\s*{`)
+	rpcRegex     = regexp.MustCompile(`rpc\s+(\w+)\s*\(\s*(\w+)\s*\)\s*returns\s*\(\s*(\w+)\s*\)`)
+)
+
+func clients(workDir string) error {
+	mod, err := os.ReadFile(fmt.Sprintf("%s/go.mod", workDir))
+	if err != nil {
+		return err
+	}
+
+	packageName := modfile.ModulePath(mod)
+	registry := parseModule(workDir)
+	for _, v := range registry.Config {
+		name := strings.TrimPrefix(v, "module:")
+		proto, err := os.ReadFile(fmt.Sprintf("%s/protos/%s.proto", workDir, name))
+		if err != nil {
+			continue
+		}
+
+		service := serviceRegex.FindStringSubmatch(string(proto))
+		if service == nil {
+			continue
+		}
+
+		model := strcase.ToCamel(name)
+		if registered, ok := registry.Definitions[name]; ok {
+			model = registered.Name
+		}
+
+		data := clientTemplate{
+			Name:    name,
+			Package: packageName,
+			Service: service[1],
+		}
+
+		body := string(proto)[strings.Index(string(proto), service[0]):]
+		for _, rpc := range rpcRegex.FindAllStringSubmatch(body, -1) {
+			method := clientMethod{Name: rpc[1], Request: rpc[2], Response: rpc[3]}
+			switch method.Name {
+			case "Get", "Delete", "Restore":
+				method.ById = method.Request == model
+			}
+
+			// only reads are retried, write may already be applied when response is lost
+			switch method.Name {
+			case "Get", "GetPaginated", "GetTrashed":
+				method.Idempotent = true
+			}
+
+			data.Methods = append(data.Methods, method)
+		}
+
+		path := fmt.Sprintf("%s/clients/%s", workDir, name)
+		if err = os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+
+		render(fmt.Sprintf("%s/client.go", path), clientPackage, data)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestClientsRetry(t *testing.T) {
+	workDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":               "module example.com/app\n",
+		"configs/modules.yaml": "modules:\n    - module:todo\n",
+		"protos/todo.proto": `service TodoService {
+    rpc GetPaginated (PaginationRequest) returns (TodoPaginatedResponse) {}
+    rpc Create (Todo) returns (Todo) {}
+    rpc Update (Todo) returns (Todo) {}
+    rpc Get (Todo) returns (Todo) {}
+    rpc Delete (Todo) returns (TodoResponse) {}
+    rpc BatchCreate (TodoBatchRequest) returns (TodoBatchResponse) {}
+    rpc GetTrashed (PaginationRequest) returns (TodoPaginatedResponse) {}
+}
+`,
+	}
+
+	for name, content := range files {
+		path := fmt.Sprintf("%s/%s", workDir, name)
+		if err := os.MkdirAll(path[:strings.LastIndex(path, "/")], 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := clients(workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(fmt.Sprintf("%s/clients/todo/client.go", workDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"GetPaginated": "call",
+		"Get":          "call",
+		"GetTrashed":   "call",
+		"Create":       "attempt",
+		"Update":       "attempt",
+		"Delete":       "attempt",
+		"BatchCreate":  "attempt",
+	}
+
+	for method, invoke := range cases {
+		t.Run(method, func(t *testing.T) {
+			start := strings.Index(string(content), fmt.Sprintf("func (c *Client) %s(", method))
+			if start == -1 {
+				t.Fatalf("method %s is not generated", method)
+			}
+
+			body := string(content)[start:]
+			body = body[:strings.Index(body, "\n}\n")]
+			if !strings.Contains(body, fmt.Sprintf("err := c.%s(ctx, ", invoke)) {
+				t.Errorf("expected %s to use c.%s, got\n%s", method, invoke, body)
+			}
+		})
+	}
+}
@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
+	engine "text/template"
 	"time"
 
 	"github.com/bimalabs/cli/bima"
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
+	"github.com/iancoleman/strcase"
+	"golang.org/x/mod/modfile"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -26,7 +30,7 @@ APP_SECRET=%s
 API_PREFIX=/api/v1
 `
 
-	adapter = `package adapters
+	adapter = `package {{.Package}}
 
 import (
     "context"
@@ -35,36 +39,36 @@ import (
     "github.com/vcraescu/go-paginator/v2"
 )
 
-type %s struct {
+type {{.Name}} struct {
 }
 
-func (a *%s) CreateAdapter(ctx context.Context, paginator paginations.Pagination) paginator.Adapter {
+func (a *{{.Name}}) CreateAdapter(ctx context.Context, paginator paginations.Pagination) paginator.Adapter {
     // TODO
 
     return nil
 }
 `
 
-	driver = `package drivers
+	driver = `package {{.Package}}
 
 import (
     "gorm.io/gorm"
 )
 
-type %s string
+type {{.Name}} string
 
-func (_ %s) Connect(host string, port int, user string, password string, dbname string, debug bool) *gorm.DB {
+func (_ {{.Name}}) Connect(host string, port int, user string, password string, dbname string, debug bool) *gorm.DB {
     // TODO
 
     return nil
 }
 
-func (m %s) Name() string {
+func (m {{.Name}}) Name() string {
     return string(m)
 }
 `
 
-	route = `package routes
+	route = `package {{.Package}}
 
 import (
     "net/http"
@@ -73,59 +77,109 @@ import (
     "google.golang.org/grpc"
 )
 
-type %s struct {
+type {{.Name}} struct {
 }
 
-func (r *%s) Path() string {
-    return "/%s"
+func (r *{{.Name}}) Path() string {
+    return "/{{.Path}}"
 }
 
-func (r *%s) Method() string {
+func (r *{{.Name}}) Method() string {
     return http.MethodGet
 }
 
-func (r *%s) SetClient(client *grpc.ClientConn) {
+func (r *{{.Name}}) SetClient(client *grpc.ClientConn) {
     // TODO
 }
 
-func (r *%s) Middlewares() []middlewares.Middleware {
+func (r *{{.Name}}) Middlewares() []middlewares.Middleware {
     // TODO
 
     return nil
 }
 
-func (r *%s) Handle(response http.ResponseWriter, request *http.Request, params map[string]string) {
+func (r *{{.Name}}) Handle(response http.ResponseWriter, request *http.Request, params map[string]string) {
     // TODO
 }
 `
 
-	middleware = `package middlewares
+	middleware = `package {{.Package}}
 
 import (
     "net/http"
 )
 
-type %s struct {
+type {{.Name}} struct {
 }
 
-func (m *%s) Attach(request *http.Request, response http.ResponseWriter) bool {
+func (m *{{.Name}}) Attach(request *http.Request, response http.ResponseWriter) bool {
     // TODO
 
     return false
 }
 
-func (m *%s) Priority() int {
+func (m *{{.Name}}) Priority() int {
     return 0
 }
 `
+
+	dic = `package {{.Package}}
+
+import (
+	"github.com/bimalabs/framework/v4"
+	"github.com/sarulabs/dingo/v4"
+)
+
+var Dic = []dingo.Def{
+}
+`
+
+	serviceDefinition = `
+	{
+		Name:  "{{.Service}}",
+		Scope: bima.Application,
+		Build: (*{{.Name}})(nil),
+	},`
+
+	driverDefinition = `
+	{
+		Name:  "{{.Service}}",
+		Scope: bima.Application,
+		Build: func() ({{.Name}}, error) {
+			return {{.Name}}("{{.Key}}"), nil
+		},
+	},`
 )
 
+var packageRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 type (
 	App        string
 	Middleware string
 	Driver     string
 	Adapter    string
 	Route      string
+
+	scaffolding struct {
+		Kind       string
+		Folder     string
+		Label      string
+		Progress   string
+		Template   string
+		Service    string
+		Config     string
+		Definition string
+	}
+
+	scaffoldData struct {
+		Name    string
+		File    string
+		Path    string
+		Package string
+		Dir     string
+		Key     string
+		Service string
+	}
 )
 
 func (a App) Create() error {
@@ -149,9 +203,92 @@ func (a App) Create() error {
 	return err
 }
 
-func (m Middleware) Create() error {
+func (m Middleware) Create(force bool) error {
+	return m.scaffold().create(string(m), force)
+}
+
+func (m Middleware) Remove(dryRun bool) error {
+	return m.scaffold().remove(string(m), dryRun)
+}
+
+func (m Middleware) scaffold() scaffolding {
+	return scaffolding{
+		Kind:       "middleware",
+		Folder:     "middlewares",
+		Label:      "Middleware",
+		Progress:   "middleware",
+		Template:   middleware,
+		Service:    "bima:middleware:%s",
+		Config:     "middlewares",
+		Definition: serviceDefinition,
+	}
+}
+
+func (d Driver) Create(force bool) error {
+	return d.scaffold().create(string(d), force)
+}
+
+func (d Driver) Remove(dryRun bool) error {
+	return d.scaffold().remove(string(d), dryRun)
+}
+
+func (d Driver) scaffold() scaffolding {
+	return scaffolding{
+		Kind:       "driver",
+		Folder:     "drivers",
+		Label:      "Driver",
+		Progress:   "database driver",
+		Template:   driver,
+		Service:    "bima:driver:%s",
+		Config:     "drivers",
+		Definition: driverDefinition,
+	}
+}
+
+func (a Adapter) Create(force bool) error {
+	return a.scaffold().create(string(a), force)
+}
+
+func (a Adapter) Remove(dryRun bool) error {
+	return a.scaffold().remove(string(a), dryRun)
+}
+
+func (a Adapter) scaffold() scaffolding {
+	return scaffolding{
+		Kind:       "adapter",
+		Folder:     "adapters",
+		Label:      "Adapter",
+		Progress:   "pagination adapter",
+		Template:   adapter,
+		Service:    "bima:pagination:adapter:%s",
+		Definition: serviceDefinition,
+	}
+}
+
+func (r Route) Create(force bool) error {
+	return r.scaffold().create(string(r), force)
+}
+
+func (r Route) Remove(dryRun bool) error {
+	return r.scaffold().remove(string(r), dryRun)
+}
+
+func (r Route) scaffold() scaffolding {
+	return scaffolding{
+		Kind:       "route",
+		Folder:     "routes",
+		Label:      "Route",
+		Progress:   "route placeholder",
+		Template:   route,
+		Service:    "bima:route:%s",
+		Config:     "routes",
+		Definition: serviceDefinition,
+	}
+}
+
+func (s scaffolding) create(name string, force bool) error {
 	progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
-	progress.Suffix = " Creating middleware... "
+	progress.Suffix = fmt.Sprintf(" Creating %s... ", s.Progress)
 	progress.Start()
 	time.Sleep(1 * time.Second)
 
@@ -162,100 +299,130 @@ func (m Middleware) Create() error {
 		return err
 	}
 
-	err = os.MkdirAll(fmt.Sprintf("%s/middlewares", wd), 0755)
+	data, err := s.data(name)
 	if err != nil {
 		progress.Stop()
 
 		return err
 	}
 
-	f, err := os.Create(fmt.Sprintf("%s/middlewares/%s.go", wd, strings.ToLower(string(m))))
+	err = os.MkdirAll(fmt.Sprintf("%s/%s", wd, data.Dir), 0755)
 	if err != nil {
 		progress.Stop()
 
 		return err
 	}
 
-	name := cases.Title(language.English).String(string(m))
-	_, err = f.WriteString(fmt.Sprintf(middleware, name, name, name))
+	text, err := s.source(wd, data)
 	if err != nil {
 		progress.Stop()
 
 		return err
 	}
 
-	_ = f.Sync()
-	_ = f.Close()
-
-	if err := Call("clean"); err != nil {
+	path := fmt.Sprintf("%s/%s.go", data.Dir, data.File)
+	current, err := os.ReadFile(fmt.Sprintf("%s/%s", wd, path))
+	exist := err == nil
+	if exist && string(current) != text && !force {
 		progress.Stop()
-		color.New(color.FgRed).Println("Error cleaning dependencies")
+		fmt.Print(difference(path, string(current), text))
 
-		return err
+		return fmt.Errorf("%s already exists, use --force to overwrite", path)
 	}
 
-	progress.Stop()
-	fmt.Printf("Middleware %s has been created\n", color.New(color.FgGreen).Sprint(name))
-
-	return nil
-}
+	if !exist || string(current) != text {
+		err = os.WriteFile(fmt.Sprintf("%s/%s", wd, path), []byte(text), 0644)
+		if err != nil {
+			progress.Stop()
 
-func (d Driver) Create() error {
-	progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
-	progress.Suffix = " Creating database driver... "
-	progress.Start()
-	time.Sleep(1 * time.Second)
+			return err
+		}
+	}
 
-	wd, err := os.Getwd()
-	if err != nil {
+	registered := s.register(wd, data)
+	if exist && string(current) == text && !registered {
 		progress.Stop()
+		fmt.Printf("%s %s is up to date\n", s.Label, color.New(color.FgGreen).Sprint(data.Name))
 
-		return err
+		return nil
 	}
 
-	err = os.MkdirAll(fmt.Sprintf("%s/drivers", wd), 0755)
-	if err != nil {
+	if err := Call("clean"); err != nil {
 		progress.Stop()
+		color.New(color.FgRed).Println("Error cleaning dependencies")
 
 		return err
 	}
 
-	f, err := os.Create(fmt.Sprintf("%s/drivers/%s.go", wd, strings.ToLower(string(d))))
-	if err != nil {
+	if err := Call("dump"); err != nil {
 		progress.Stop()
+		color.New(color.FgRed).Println("Error updating services container")
 
 		return err
 	}
 
-	name := cases.Title(language.English).String(string(d))
-	_, err = f.WriteString(fmt.Sprintf(driver, name, name, name))
-	if err != nil {
-		progress.Stop()
+	progress.Stop()
+	fmt.Printf("%s %s has been created\n", s.Label, color.New(color.FgGreen).Sprint(data.Name))
 
-		return err
+	return nil
+}
+
+func (s scaffolding) register(workDir string, data scaffoldData) bool {
+	provider := fmt.Sprintf("%s/configs/provider.go", workDir)
+	mod, err := os.ReadFile(fmt.Sprintf("%s/go.mod", workDir))
+	if err != nil {
+		return false
 	}
 
-	_ = f.Sync()
-	_ = f.Close()
+	if _, err = os.Stat(provider); err != nil {
+		return false
+	}
 
-	if err := Call("clean"); err != nil {
-		progress.Stop()
-		color.New(color.FgRed).Println("Error cleaning dependencies")
+	if s.Config != "" {
+		enlist(workDir, s.Config, data.Key)
+	}
 
-		return err
+	path := fmt.Sprintf("%s/%s/dic.go", workDir, data.Dir)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		render(path, dic, data)
 	}
 
-	progress.Stop()
-	fmt.Printf("Driver %s has been created\n", color.New(color.FgGreen).Sprint(name))
+	registered := false
+	patch(path, func(codeblock string) string {
+		if strings.Contains(codeblock, fmt.Sprintf("%q", data.Service)) {
+			return codeblock
+		}
 
-	return nil
+		registered = true
+
+		return strings.Replace(codeblock, "var Dic = []dingo.Def{", fmt.Sprintf("var Dic = []dingo.Def{%s", execute(s.Definition, data)), 1)
+	})
+
+	patch(provider, func(codeblock string) string {
+		marker := fmt.Sprintf("/*@%s*/", data.Dir)
+		if strings.Contains(codeblock, marker) {
+			return codeblock
+		}
+
+		registered = true
+		alias := strcase.ToLowerCamel(strings.ReplaceAll(data.Dir, "/", "_"))
+		imported := fmt.Sprintf("%q", fmt.Sprintf("%s/%s", modfile.ModulePath(mod), data.Dir))
+		if alias != data.Package {
+			imported = fmt.Sprintf("%s %s", alias, imported)
+		}
+
+		register := fmt.Sprintf("%sif err := p.AddDefSlice(%s.Dic); err != nil {return err}", marker, alias)
+
+		return provide(codeblock, imported, register)
+	})
+
+	return registered
 }
 
-func (a Adapter) Create() error {
+func (s scaffolding) remove(name string, dryRun bool) error {
 	progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
-	progress.Suffix = " Creating pagination adapter... "
+	progress.Suffix = fmt.Sprintf(" Removing %s... ", s.Progress)
 	progress.Start()
-	time.Sleep(1 * time.Second)
 
 	wd, err := os.Getwd()
 	if err != nil {
@@ -264,96 +431,171 @@ func (a Adapter) Create() error {
 		return err
 	}
 
-	err = os.MkdirAll(fmt.Sprintf("%s/adapters", wd), 0755)
+	data, err := s.data(name)
 	if err != nil {
 		progress.Stop()
 
 		return err
 	}
 
-	f, err := os.Create(fmt.Sprintf("%s/adapters/%s.go", wd, strings.ToLower(string(a))))
-	if err != nil {
-		progress.Stop()
+	path := fmt.Sprintf("%s/%s.go", data.Dir, data.File)
+	changes := []string{}
+	if _, err := os.Stat(fmt.Sprintf("%s/%s", wd, path)); err == nil {
+		changes = append(changes, fmt.Sprintf("delete %s", path))
+		if !dryRun {
+			os.Remove(fmt.Sprintf("%s/%s", wd, path))
+		}
+	}
 
-		return err
+	changes = append(changes, s.unregister(wd, data, dryRun)...)
+	progress.Stop()
+	if len(changes) == 0 {
+		return fmt.Errorf("%s %s is not found", s.Label, data.Name)
 	}
 
-	name := cases.Title(language.English).String(string(a))
-	_, err = f.WriteString(fmt.Sprintf(adapter, name, name))
-	if err != nil {
-		progress.Stop()
+	if dryRun {
+		fmt.Printf("Removing %s %s will:\n", s.Kind, color.New(color.FgGreen).Sprint(data.Name))
+		for _, v := range changes {
+			fmt.Printf("  - %s\n", v)
+		}
 
-		return err
+		return nil
 	}
 
-	_ = f.Sync()
-	_ = f.Close()
+	if data.Dir != s.Folder {
+		os.Remove(fmt.Sprintf("%s/%s", wd, data.Dir))
+	}
 
+	progress.Start()
 	if err := Call("clean"); err != nil {
 		progress.Stop()
-
 		color.New(color.FgRed).Println("Error cleaning dependencies")
 
 		return err
 	}
 
+	if err := Call("dump"); err != nil {
+		progress.Stop()
+		color.New(color.FgRed).Println("Error updating services container")
+
+		return err
+	}
+
 	progress.Stop()
-	fmt.Printf("Adapter %s has been created\n", color.New(color.FgGreen).Sprint(name))
+	fmt.Printf("%s %s has been removed\n", s.Label, color.New(color.FgGreen).Sprint(data.Name))
 
 	return nil
 }
 
-func (r Route) Create() error {
-	progress := spinner.New(spinner.CharSets[bima.SpinerIndex], bima.Duration)
-	progress.Suffix = " Creating route placeholder... "
-	progress.Start()
-	time.Sleep(1 * time.Second)
+func (s scaffolding) data(name string) (scaffoldData, error) {
+	segments := strings.Split(strings.Trim(name, "/"), "/")
+	base := segments[len(segments)-1]
+	dirs := []string{s.Folder}
+	keys := []string{}
+	for _, v := range segments[:len(segments)-1] {
+		dirs = append(dirs, strcase.ToSnake(v))
+		keys = append(keys, strcase.ToKebab(v))
+	}
 
-	wd, err := os.Getwd()
-	if err != nil {
-		progress.Stop()
+	keys = append(keys, strcase.ToKebab(base))
+	data := scaffoldData{
+		Name:    strcase.ToCamel(base),
+		File:    strcase.ToSnake(base),
+		Path:    strings.Join(keys, "/"),
+		Package: dirs[len(dirs)-1],
+		Dir:     strings.Join(dirs, "/"),
+		Key:     strings.Join(keys, ":"),
+	}
+	data.Service = fmt.Sprintf(s.Service, data.Key)
 
-		return err
+	for _, v := range append(dirs, data.File) {
+		if !packageRegex.MatchString(v) {
+			return data, fmt.Errorf("%s is not valid %s name", name, s.Kind)
+		}
 	}
 
-	err = os.MkdirAll(fmt.Sprintf("%s/routes", wd), 0755)
-	if err != nil {
-		progress.Stop()
+	if data.File == "dic" {
+		return data, fmt.Errorf("%s is reserved for services container definition", data.File)
+	}
 
-		return err
+	return data, nil
+}
+
+func (s scaffolding) unregister(workDir string, data scaffoldData, dryRun bool) []string {
+	changes := []string{}
+	if s.Config != "" && listed(workDir, s.Config, data.Key) {
+		changes = append(changes, fmt.Sprintf("remove %s from configs/%s.yaml", data.Key, s.Config))
+		if !dryRun {
+			delist(workDir, s.Config, data.Key)
+		}
 	}
 
-	lName := strings.ToLower(string(r))
-	f, err := os.Create(fmt.Sprintf("%s/routes/%s.go", wd, lName))
+	path := fmt.Sprintf("%s/%s/dic.go", workDir, data.Dir)
+	content, err := os.ReadFile(path)
 	if err != nil {
-		progress.Stop()
+		return changes
+	}
 
-		return err
+	regex := regexp.MustCompile(fmt.Sprintf(`(?s)\n\t\{\n\t\tName:\s+%s,\n.*?\n\t\},`, regexp.QuoteMeta(fmt.Sprintf("%q", data.Service))))
+	if !regex.MatchString(string(content)) {
+		return changes
+	}
+
+	changes = append(changes, fmt.Sprintf("unregister %s from %s/dic.go", data.Service, data.Dir))
+	codeblock := regex.ReplaceAllString(string(content), "")
+	if strings.Contains(codeblock, "Name:") {
+		if !dryRun {
+			_ = os.WriteFile(path, []byte(codeblock), 0644)
+		}
+
+		return changes
+	}
+
+	changes = append(changes, fmt.Sprintf("delete %s/dic.go", data.Dir))
+	if !dryRun {
+		os.Remove(path)
 	}
 
-	name := cases.Title(language.English).String(string(r))
-	_, err = f.WriteString(fmt.Sprintf(route, name, name, lName, name, name, name, name))
+	provider := fmt.Sprintf("%s/configs/provider.go", workDir)
+	mod, _ := os.ReadFile(fmt.Sprintf("%s/go.mod", workDir))
+	file, err := os.ReadFile(provider)
 	if err != nil {
-		progress.Stop()
+		return changes
+	}
 
-		return err
+	codeblock = string(file)
+	for _, v := range []string{regexp.QuoteMeta(fmt.Sprintf("/*@%s*/", data.Dir)), regexp.QuoteMeta(fmt.Sprintf("%q", fmt.Sprintf("%s/%s", modfile.ModulePath(mod), data.Dir)))} {
+		codeblock = regexp.MustCompile(fmt.Sprintf("(?m)[\r\n]+^.*%s.*$", v)).ReplaceAllString(codeblock, "")
 	}
 
-	_ = f.Sync()
-	_ = f.Close()
+	if codeblock != string(file) {
+		changes = append(changes, fmt.Sprintf("unregister %s from configs/provider.go", data.Dir))
+		if !dryRun {
+			_ = os.WriteFile(provider, []byte(codeblock), 0644)
+		}
+	}
 
-	if err := Call("clean"); err != nil {
-		progress.Stop()
+	return changes
+}
 
-		color.New(color.FgRed).Println("Error cleaning dependencies")
+func (s scaffolding) source(workDir string, data scaffoldData) (string, error) {
+	text := s.Template
+	path := fmt.Sprintf("%s/%s/%s.tmpl", workDir, parseProject(workDir).templates(), s.Kind)
+	if content, err := os.ReadFile(path); err == nil {
+		text = string(content)
+	}
 
-		return err
+	template, err := engine.New(s.Kind).Parse(text)
+	if err != nil {
+		return "", err
 	}
 
-	progress.Stop()
-	fmt.Printf("Route %s has been created\n", color.New(color.FgGreen).Sprint(name))
+	var buffer strings.Builder
+	if err = template.Execute(&buffer, data); err != nil {
+		return "", err
+	}
 
-	return nil
+	return buffer.String(), nil
 }
 
 func createApp(name string) error {
@@ -467,3 +709,91 @@ func createApp(name string) error {
 
 	return nil
 }
+
+func difference(path string, current string, text string) string {
+	before := strings.Split(strings.TrimSuffix(current, "\n"), "\n")
+	after := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	changes := []string{}
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			changes = append(changes, " "+before[i])
+			i++
+			j++
+		case i < len(before) && (j == len(after) || common[i+1][j] >= common[i][j+1]):
+			changes = append(changes, "-"+before[i])
+			i++
+		default:
+			changes = append(changes, "+"+after[j])
+			j++
+		}
+	}
+
+	visible := make([]bool, len(changes))
+	for k, change := range changes {
+		if change[0] == ' ' {
+			continue
+		}
+
+		for n := k - 3; n <= k+3; n++ {
+			if n >= 0 && n < len(changes) {
+				visible[n] = true
+			}
+		}
+	}
+
+	removed := color.New(color.FgRed)
+	added := color.New(color.FgGreen)
+
+	var result strings.Builder
+	result.WriteString(removed.Sprintf("--- %s (current)\n", path))
+	result.WriteString(added.Sprintf("+++ %s (generated)\n", path))
+
+	hidden := 0
+	for k, change := range changes {
+		if !visible[k] {
+			hidden++
+
+			continue
+		}
+
+		if hidden > 0 {
+			result.WriteString(fmt.Sprintf("@@ %d unchanged line(s) @@\n", hidden))
+			hidden = 0
+		}
+
+		switch change[0] {
+		case '-':
+			result.WriteString(removed.Sprintln(change))
+		case '+':
+			result.WriteString(added.Sprintln(change))
+		default:
+			result.WriteString(fmt.Sprintln(change))
+		}
+	}
+
+	if hidden > 0 {
+		result.WriteString(fmt.Sprintf("@@ %d unchanged line(s) @@\n", hidden))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,141 @@
+package tool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestDifference(t *testing.T) {
+	color.NoColor = true
+
+	cases := []struct {
+		name     string
+		current  string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "identical",
+			current:  "a\nb\nc\n",
+			text:     "a\nb\nc\n",
+			expected: []string{"@@ 3 unchanged line(s) @@"},
+		},
+		{
+			name:     "changed line",
+			current:  "a\nb\nc\n",
+			text:     "a\nx\nc\n",
+			expected: []string{" a", "-b", "+x", " c"},
+		},
+		{
+			name:     "added line",
+			current:  "a\nb\n",
+			text:     "a\nb\nc\n",
+			expected: []string{" a", " b", "+c"},
+		},
+		{
+			name:     "removed line",
+			current:  "a\nb\nc\n",
+			text:     "a\nc\n",
+			expected: []string{" a", "-b", " c"},
+		},
+		{
+			name:     "hidden context",
+			current:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			text:     "1\n2\n3\n4\n5\n6\n7\n8\n9\nx\n",
+			expected: []string{"@@ 6 unchanged line(s) @@", " 7", " 8", " 9", "-10", "+x"},
+		},
+		{
+			name:     "context between changes",
+			current:  "x\n1\n2\n3\n4\n5\n6\n7\n8\ny\n",
+			text:     "1\n2\n3\n4\n5\n6\n7\n8\n",
+			expected: []string{"-x", " 1", " 2", " 3", "@@ 2 unchanged line(s) @@", " 6", " 7", " 8", "-y"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			lines := strings.Split(strings.TrimSuffix(difference("file.go", c.current, c.text), "\n"), "\n")
+			header := []string{"--- file.go (current)", "+++ file.go (generated)"}
+			expected := append(header, c.expected...)
+			if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+				t.Errorf("expected\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+			}
+		})
+	}
+}
+
+func TestScaffoldingData(t *testing.T) {
+	cases := []struct {
+		name     string
+		scaffold scaffolding
+		input    string
+		expected scaffoldData
+		err      bool
+	}{
+		{
+			name:     "camel case",
+			scaffold: Middleware("").scaffold(),
+			input:    "rateLimit",
+			expected: scaffoldData{Name: "RateLimit", File: "rate_limit", Path: "rate-limit", Package: "middlewares", Dir: "middlewares", Key: "rate-limit", Service: "bima:middleware:rate-limit"},
+		},
+		{
+			name:     "kebab case",
+			scaffold: Driver("").scaffold(),
+			input:    "click-house",
+			expected: scaffoldData{Name: "ClickHouse", File: "click_house", Path: "click-house", Package: "drivers", Dir: "drivers", Key: "click-house", Service: "bima:driver:click-house"},
+		},
+		{
+			name:     "nested",
+			scaffold: Route("").scaffold(),
+			input:    "admin/audit_log",
+			expected: scaffoldData{Name: "AuditLog", File: "audit_log", Path: "admin/audit-log", Package: "admin", Dir: "routes/admin", Key: "admin:audit-log", Service: "bima:route:admin:audit-log"},
+		},
+		{
+			name:     "adapter",
+			scaffold: Adapter("").scaffold(),
+			input:    "/elastic/",
+			expected: scaffoldData{Name: "Elastic", File: "elastic", Path: "elastic", Package: "adapters", Dir: "adapters", Key: "elastic", Service: "bima:pagination:adapter:elastic"},
+		},
+		{
+			name:     "invalid name",
+			scaffold: Route("").scaffold(),
+			input:    "9lives",
+			err:      true,
+		},
+		{
+			name:     "invalid package",
+			scaffold: Route("").scaffold(),
+			input:    "2fa/audit",
+			err:      true,
+		},
+		{
+			name:     "reserved name",
+			scaffold: Middleware("").scaffold(),
+			input:    "dic",
+			err:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := c.scaffold.data(c.input)
+			if c.err {
+				if err == nil {
+					t.Errorf("expected error, got %+v", data)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if data != c.expected {
+				t.Errorf("expected %+v, got %+v", c.expected, data)
+			}
+		})
+	}
+}
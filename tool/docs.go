@@ -0,0 +1,256 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bimalabs/framework/v4/configs"
+	"github.com/fatih/color"
+	"github.com/iancoleman/strcase"
+)
+
+type (
+	Docs string
+
+	postmanCollection struct {
+		Info     postmanInfo       `json:"info"`
+		Variable []postmanVariable `json:"variable"`
+		Item     []postmanFolder   `json:"item"`
+	}
+
+	postmanInfo struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	}
+
+	postmanVariable struct {
+		Key      string `json:"key"`
+		Value    string `json:"value"`
+		Disabled bool   `json:"disabled,omitempty"`
+	}
+
+	postmanFolder struct {
+		Name string        `json:"name"`
+		Item []postmanItem `json:"item"`
+	}
+
+	postmanItem struct {
+		Name    string         `json:"name"`
+		Request postmanRequest `json:"request"`
+	}
+
+	postmanRequest struct {
+		Method      string            `json:"method"`
+		Description string            `json:"description,omitempty"`
+		Header      []postmanVariable `json:"header"`
+		Url         postmanUrl        `json:"url"`
+		Body        *postmanBody      `json:"body,omitempty"`
+	}
+
+	postmanUrl struct {
+		Raw      string            `json:"raw"`
+		Host     []string          `json:"host"`
+		Path     []string          `json:"path"`
+		Query    []postmanVariable `json:"query,omitempty"`
+		Variable []postmanVariable `json:"variable,omitempty"`
+	}
+
+	postmanBody struct {
+		Mode    string                 `json:"mode"`
+		Raw     string                 `json:"raw"`
+		Options map[string]interface{} `json:"options"`
+	}
+
+	httpRequest struct {
+		Name   string
+		Method string
+		Url    string
+		Auth   bool
+		Body   string
+	}
+
+	httpFile struct {
+		BaseUrl   string
+		ApiPrefix string
+		Auth      bool
+		Variables map[string]string
+		Requests  []httpRequest
+	}
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+func (d Docs) env() configs.Env {
+	env := configs.Env{}
+	config(&env, string(d), filepath.Ext(string(d)))
+	if env.HttpPort == 0 {
+		env.HttpPort = 7777
+	}
+
+	return env
+}
+
+func (d Docs) Collection(output string) error {
+	workDir, _ := os.Getwd()
+	modules, err := swaggers(workDir)
+	if err != nil {
+		return err
+	}
+
+	env := d.env()
+	name := env.Service
+	if name == "" {
+		name = filepath.Base(workDir)
+	}
+
+	baseUrl := fmt.Sprintf("http://localhost:%d", env.HttpPort)
+	collection := postmanCollection{
+		Info: postmanInfo{Name: name, Schema: postmanSchema},
+		Variable: []postmanVariable{
+			{Key: "baseUrl", Value: baseUrl},
+			{Key: "token", Value: ""},
+		},
+	}
+
+	if err = os.MkdirAll(fmt.Sprintf("%s/http", output), 0755); err != nil {
+		return err
+	}
+
+	for _, m := range modules {
+		folder := postmanFolder{Name: m.Name}
+		file := httpFile{
+			BaseUrl:   baseUrl,
+			ApiPrefix: env.ApiPrefix,
+			Auth:      len(m.Spec.Security) > 0,
+			Variables: map[string]string{},
+		}
+
+		for _, e := range m.Spec.endpoints() {
+			path := e.Path
+			if env.ApiPrefix != "" && strings.HasPrefix(path, env.ApiPrefix) {
+				path = fmt.Sprintf("[prefix]%s", strings.TrimPrefix(path, env.ApiPrefix))
+			}
+
+			request := postmanRequest{
+				Method:      strings.ToUpper(e.Method),
+				Description: e.Operation.Summary,
+				Header:      []postmanVariable{{Key: "Accept", Value: "application/json"}},
+			}
+
+			if file.Auth {
+				request.Header = append(request.Header, postmanVariable{Key: "Authorization", Value: "Bearer {{token}}"})
+			}
+
+			body := ""
+			query := []string{}
+			for _, p := range e.Operation.Parameters {
+				value := example(p.schema(), m.Spec.Definitions, 0)
+				switch p.In {
+				case "path":
+					request.Url.Variable = append(request.Url.Variable, postmanVariable{Key: p.Name, Value: text(value)})
+					file.Variables[p.Name] = text(value)
+				case "query":
+					request.Url.Query = append(request.Url.Query, postmanVariable{Key: p.Name, Value: text(value), Disabled: !p.Required})
+					if p.Required {
+						query = append(query, fmt.Sprintf("%s=%s", p.Name, text(value)))
+					}
+				case "body":
+					content, _ := json.MarshalIndent(value, "", "    ")
+					body = string(content)
+				}
+			}
+
+			raw := pathParamRegex.ReplaceAllString(e.Path, ":$1")
+			request.Url.Raw = fmt.Sprintf("{{baseUrl}}%s", raw)
+			request.Url.Host = []string{"{{baseUrl}}"}
+			request.Url.Path = strings.Split(strings.Trim(raw, "/"), "/")
+
+			if len(query) > 0 {
+				request.Url.Raw = fmt.Sprintf("%s?%s", request.Url.Raw, strings.Join(query, "&"))
+			}
+
+			if body != "" {
+				request.Header = append(request.Header, postmanVariable{Key: "Content-Type", Value: "application/json"})
+				request.Body = &postmanBody{
+					Mode:    "raw",
+					Raw:     body,
+					Options: map[string]interface{}{"raw": map[string]string{"language": "json"}},
+				}
+			}
+
+			folder.Item = append(folder.Item, postmanItem{Name: e.Name, Request: request})
+
+			target := fmt.Sprintf("{{baseUrl}}%s", strings.Replace(pathParamRegex.ReplaceAllString(path, "{{$1}}"), "[prefix]", "{{apiPrefix}}", 1))
+			if len(query) > 0 {
+				target = fmt.Sprintf("%s?%s", target, strings.Join(query, "&"))
+			}
+
+			file.Requests = append(file.Requests, httpRequest{
+				Name:   e.Name,
+				Method: request.Method,
+				Url:    target,
+				Auth:   file.Auth,
+				Body:   body,
+			})
+		}
+
+		collection.Item = append(collection.Item, folder)
+		render(fmt.Sprintf("%s/http/%s.http", output, m.File), httpCollection, file)
+	}
+
+	content, err := json.MarshalIndent(collection, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fmt.Sprintf("%s/%s.postman_collection.json", output, strcase.ToSnake(name)), content, 0644)
+}
+
+func (d Docs) Rebuild() error {
+	workDir, _ := os.Getwd()
+	report, err := rebuild(workDir)
+	if err != nil {
+		return err
+	}
+
+	warning := color.New(color.FgYellow)
+	for _, v := range report.Added {
+		fmt.Printf("Module %s added\n", v)
+	}
+
+	for _, v := range report.Removed {
+		fmt.Printf("Module %s removed\n", v)
+	}
+
+	for _, v := range report.Missing {
+		warning.Printf("Swagger file %s is missing, run bima generate\n", v)
+	}
+
+	for _, v := range report.Extra {
+		warning.Printf("Swagger file %s is not registered in %s\n", v, c)
+	}
+
+	color.New(color.FgGreen).Printf("swaggers/modules.json rebuilt with %d module(s)\n", len(report.Modules))
+
+	return nil
+}
+
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+
+		return text(v[0])
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,988 @@
+package tool
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	engine "text/template"
+
+	"github.com/bimalabs/generators"
+	"github.com/iancoleman/strcase"
+	"gopkg.in/yaml.v2"
+)
+
+const openapiField = "grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field"
+
+// queriesHelper is copied as is into project, see assets/queries
+//
+//go:embed assets/queries/queries.go
+var queriesHelper []byte
+
+type (
+	typeOverride struct {
+		types typeMap
+	}
+
+	apiPath struct {
+		path string
+	}
+
+	connection struct {
+		name string
+	}
+
+	authorization struct {
+		enabled bool
+		path    string
+	}
+
+	listing struct {
+		queries map[string]fieldQuery
+	}
+
+	event struct {
+		enabled bool
+	}
+
+	batch struct {
+		enabled bool
+		tenant  bool
+		events  bool
+		path    string
+	}
+
+	spreadsheet struct {
+		enabled bool
+		tenant  bool
+		path    string
+		types   typeMap
+	}
+
+	tenancy struct {
+		enabled bool
+		path    string
+	}
+
+	encryption struct {
+		fields map[string]bool
+	}
+
+	trash struct {
+		enabled    *bool
+		tenant     bool
+		connection string
+		path       string
+	}
+
+	csvColumn struct {
+		Header string
+		Format string
+		Parse  string
+	}
+
+	queryTemplate struct {
+		Column string
+		fieldQuery
+	}
+
+	templateData struct {
+		generators.Template
+		Name       string
+		Connection string
+		Env        string
+		Base       string
+		Queries    []queryTemplate
+		Filters    string
+		Sorts      string
+		Searches   string
+		Path       string
+		Fields     []csvColumn
+		Imports    []string
+		Tenant     bool
+		Events     bool
+		Encrypted  []string
+	}
+)
+
+func (g *typeOverride) Generate(template generators.Template, modulePath string, driver string) {
+	patch(protoFile(template), func(codeblock string) string {
+		for _, v := range template.Columns {
+			option := ""
+			if length := g.types.Length(v.ProtobufType); length > 0 {
+				option = fmt.Sprintf(" [(%s) = {max_length: %d}]", openapiField, length)
+			}
+
+			regex := regexp.MustCompile(fmt.Sprintf(`(?m)^(\s*)%s(\s+%s\s*=\s*\d+)\s*;`, regexp.QuoteMeta(v.ProtobufType), v.NameUnderScore))
+			codeblock = regex.ReplaceAllString(codeblock, fmt.Sprintf("${1}%s${2}%s;", g.types.Protobuf(v.ProtobufType), option))
+		}
+
+		return codeblock
+	})
+
+	if driver == "mongo" {
+		return
+	}
+
+	patch(fmt.Sprintf("%s/model.go", modulePath), func(codeblock string) string {
+		for _, v := range template.Columns {
+			column := g.types.Column(v.ProtobufType)
+			if column == "" {
+				continue
+			}
+
+			tag := fmt.Sprintf(`gorm:"type:%s"`, column)
+			if v.IsRequired {
+				tag = fmt.Sprintf(`%s validate:"required"`, tag)
+			}
+
+			regex := regexp.MustCompile(fmt.Sprintf("(?m)^(\\s*%s\\s+%s)[ \\t]*(`[^`]*`)?[ \\t]*$", v.Name, regexp.QuoteMeta(v.GolangType)))
+			codeblock = regex.ReplaceAllString(codeblock, fmt.Sprintf("${1} `%s`", tag))
+		}
+
+		return codeblock
+	})
+}
+
+func (g *apiPath) Generate(template generators.Template, modulePath string, driver string) {
+	if g.path == "" {
+		return
+	}
+
+	patch(protoFile(template), func(codeblock string) string {
+		regex := regexp.MustCompile(fmt.Sprintf(`"%s/%s(/|")`, regexp.QuoteMeta(template.ApiPrefix), template.ModulePluralLowercase))
+
+		return regex.ReplaceAllString(codeblock, fmt.Sprintf(`"%s/%s${1}`, template.ApiPrefix, g.path))
+	})
+}
+
+func (g *connection) Generate(template generators.Template, modulePath string, driver string) {
+	if g.name == "" {
+		return
+	}
+
+	workDir, _ := os.Getwd()
+	data := templateData{
+		Template:   template,
+		Name:       strcase.ToCamel(g.name),
+		Connection: g.name,
+		Env:        strcase.ToScreamingSnake(g.name),
+	}
+
+	dic := fmt.Sprintf("%s/connections/%s.go", workDir, strcase.ToSnake(g.name))
+	if _, err := os.Stat(dic); os.IsNotExist(err) {
+		err = os.MkdirAll(fmt.Sprintf("%s/connections", workDir), 0755)
+		if err != nil {
+			panic(err)
+		}
+
+		render(dic, connectionDic, data)
+	}
+
+	patch(fmt.Sprintf("%s/configs/provider.go", workDir), func(codeblock string) string {
+		marker := fmt.Sprintf("/*@connection:%s*/", g.name)
+		if strings.Contains(codeblock, marker) {
+			return codeblock
+		}
+
+		imported := fmt.Sprintf("%q", fmt.Sprintf("%s/connections", template.PackageName))
+		register := fmt.Sprintf("%sif err := p.AddDefSlice(connections.%s); err != nil {return err}", marker, data.Name)
+
+		return provide(codeblock, imported, register)
+	})
+
+	render(fmt.Sprintf("%s/repository.go", modulePath), connectionRepository, data)
+
+	patch(fmt.Sprintf("%s/dic.go", modulePath), func(codeblock string) string {
+		codeblock = strings.Replace(codeblock, "var Dic = []dingo.Def{", "var Dic = []dingo.Def{\n\tconnection,", 1)
+		codeblock = strings.Replace(codeblock, `dingo.Service("bima:module")`, fmt.Sprintf(`dingo.Service("module:%s:bima")`, template.ModuleLowercase), 1)
+
+		return strings.Replace(codeblock, `"Server": dingo.Service("bima:server"),`, fmt.Sprintf(`"Server": dingo.Service("bima:server"),
+            "Database": dingo.Service("bima:connection:%s"),`, g.name), 1)
+	})
+
+	patch(fmt.Sprintf("%s/server.go", modulePath), func(codeblock string) string {
+		codeblock = strings.Replace(codeblock, "    Module *Module\n", "    Module *Module\n    Database *gorm.DB\n", 1)
+
+		return strings.Replace(codeblock, "db.AutoMigrate(", "s.Database.AutoMigrate(", 1)
+	})
+}
+
+func (g *authorization) Generate(template generators.Template, modulePath string, driver string) {
+	if !g.enabled {
+		return
+	}
+
+	workDir, _ := os.Getwd()
+	roles := fmt.Sprintf("%s/authorizations/roles.go", workDir)
+	if _, err := os.Stat(roles); os.IsNotExist(err) {
+		err = os.MkdirAll(fmt.Sprintf("%s/authorizations", workDir), 0755)
+		if err != nil {
+			panic(err)
+		}
+
+		render(roles, authorizationRoles, templateData{Template: template})
+	}
+
+	render(fmt.Sprintf("%s/authorization.go", modulePath), authorizationMiddleware, templateData{
+		Template: template,
+		Base:     basePath(template, g.path),
+	})
+
+	patch(fmt.Sprintf("%s/dic.go", modulePath), func(codeblock string) string {
+		return strings.Replace(codeblock, "var Dic = []dingo.Def{", fmt.Sprintf(`var Dic = []dingo.Def{
+	{
+		Name:  "bima:middleware:%s:authorization",
+		Scope: bima.Application,
+		Build: (*Authorization)(nil),
+	},`, template.ModuleLowercase), 1)
+	})
+
+	enlist(workDir, "middlewares", fmt.Sprintf("%s:authorization", template.ModuleLowercase))
+}
+
+func (g *listing) Generate(template generators.Template, modulePath string, driver string) {
+	if driver == "mongo" || len(g.queries) == 0 {
+		return
+	}
+
+	workDir, _ := os.Getwd()
+	data := templateData{Template: template}
+	filters := []string{}
+	sorts := []string{}
+	searches := []string{}
+	for _, v := range template.Columns {
+		q, ok := g.queries[v.NameUnderScore]
+		if !ok {
+			continue
+		}
+
+		data.Queries = append(data.Queries, queryTemplate{Column: v.NameUnderScore, fieldQuery: q})
+		if len(q.Operators) > 0 {
+			filters = append(filters, fmt.Sprintf("%s (%s)", v.NameUnderScore, strings.Join(q.Operators, " ")))
+		}
+
+		if q.Sortable {
+			sorts = append(sorts, v.NameUnderScore)
+		}
+
+		if q.Searchable {
+			searches = append(searches, v.NameUnderScore)
+		}
+	}
+
+	data.Filters = strings.Join(filters, ", ")
+	data.Sorts = strings.Join(sorts, ", ")
+	data.Searches = strings.Join(searches, ", ")
+
+	helper := fmt.Sprintf("%s/queries/queries.go", workDir)
+	if _, err := os.Stat(helper); os.IsNotExist(err) {
+		err = os.MkdirAll(fmt.Sprintf("%s/queries", workDir), 0755)
+		if err != nil {
+			panic(err)
+		}
+
+		err = os.WriteFile(helper, queriesHelper, 0644)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	render(fmt.Sprintf("%s/query.go", modulePath), queryListener, data)
+
+	patch(protoFile(template), func(codeblock string) string {
+		codeblock = strings.Replace(codeblock, "\nservice ", fmt.Sprintf("\n%sservice ", execute(paginationRequest, data)), 1)
+
+		return strings.Replace(codeblock, "rpc GetPaginated (PaginationRequest)", fmt.Sprintf("rpc GetPaginated (%sPaginationRequest)", template.Module), 1)
+	})
+
+	patch(fmt.Sprintf("%s/module.go", modulePath), func(codeblock string) string {
+		codeblock = strings.Replace(codeblock, "r *grpcs.PaginationRequest)", fmt.Sprintf("r *grpcs.%sPaginationRequest)", template.Module), 1)
+
+		return strings.Replace(codeblock, "\tm.Paginator().Handle(reqeust)\n", `	m.Paginator().Handle(reqeust)
+	if err := m.query(m.Paginator(), r); err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+`, 1)
+	})
+
+	patch(fmt.Sprintf("%s/dic.go", modulePath), func(codeblock string) string {
+		return strings.Replace(codeblock, "var Dic = []dingo.Def{", fmt.Sprintf(`var Dic = []dingo.Def{
+	{
+		Name:  "bima:listener:%s:query",
+		Scope: bima.Application,
+		Build: (*Query)(nil),
+	},`, template.ModuleLowercase), 1)
+	})
+
+	enlist(workDir, "listeners", fmt.Sprintf("%s:query", template.ModuleLowercase))
+}
+
+func (g *event) Generate(template generators.Template, modulePath string, driver string) {
+	if !g.enabled {
+		return
+	}
+
+	data := templateData{Template: template}
+	render(fmt.Sprintf("%s/event.go", modulePath), eventPublisher, data)
+	render(fmt.Sprintf("%s/subscriber.go", modulePath), eventSubscriber, data)
+
+	patch(protoFile(template), func(codeblock string) string {
+		return strings.Replace(codeblock, "\nservice ", fmt.Sprintf("\n%sservice ", execute(eventMessage, data)), 1)
+	})
+
+	patch(fmt.Sprintf("%s/module.go", modulePath), func(codeblock string) string {
+		replacements := []struct {
+			pattern string
+			replace string
+		}{
+			{
+				pattern: `(?m)^([ \t]*)"github.com/bimalabs/framework/v4/loggers"\n`,
+				replace: "${1}\"github.com/bimalabs/framework/v4/loggers\"\n${1}\"github.com/bimalabs/framework/v4/messengers\"\n",
+			},
+			// Messenger is not listed in dic params, dingo autofill it when messenger service is registered and leave it nil otherwise
+			{
+				pattern: `(?m)^([ \t]*)(grpcs\.Unimplemented\w+Server)\n`,
+				replace: "${1}Messenger *messengers.Messenger\n${1}${2}\n",
+			},
+			{
+				pattern: `(?m)^([ \t]*)(r\.Id = v\.I[dD].*)\n`,
+				replace: fmt.Sprintf("${1}${2}\n${1}m.publish(ctx, Created, &grpcs.%sCreated{Data: r})\n", template.Module),
+			},
+			{
+				pattern: `(?m)^([ \t]*)m\.Cache\(\)\.Invalidate\(r\.Id\)\n(\s*return r, nil)`,
+				replace: fmt.Sprintf("${1}m.Cache().Invalidate(r.Id)\n${1}m.publish(ctx, Updated, &grpcs.%sUpdated{Data: r})\n${2}", template.Module),
+			},
+			{
+				pattern: `(?m)^([ \t]*)m\.Cache\(\)\.Invalidate\(r\.Id\)\n(\s*return &grpcs\.)`,
+				replace: fmt.Sprintf("${1}m.Cache().Invalidate(r.Id)\n${1}m.publish(ctx, Deleted, &grpcs.%sDeleted{Id: r.Id})\n${2}", template.Module),
+			},
+		}
+
+		for _, v := range replacements {
+			codeblock = regexp.MustCompile(v.pattern).ReplaceAllString(codeblock, v.replace)
+		}
+
+		return codeblock
+	})
+}
+
+func (g *batch) Generate(template generators.Template, modulePath string, driver string) {
+	if !g.enabled || driver == "mongo" {
+		return
+	}
+
+	data := templateData{
+		Template: template,
+		Base:     basePath(template, g.path),
+		Tenant:   g.tenant,
+		Events:   g.events,
+	}
+
+	render(fmt.Sprintf("%s/batch.go", modulePath), batchHandler, data)
+
+	patch(protoFile(template), func(codeblock string) string {
+		codeblock = strings.Replace(codeblock, "\nservice ", fmt.Sprintf("\n%sservice ", execute(batchMessage, data)), 1)
+
+		end := strings.LastIndex(codeblock, "}")
+		if end == -1 {
+			return codeblock
+		}
+
+		return fmt.Sprintf("%s%s%s", codeblock[:end], execute(batchService, data), codeblock[end:])
+	})
+}
+
+func (g *spreadsheet) Generate(template generators.Template, modulePath string, driver string) {
+	if !g.enabled || driver == "mongo" {
+		return
+	}
+
+	path := g.path
+	if path == "" {
+		path = template.ModulePluralLowercase
+	}
+
+	workDir, _ := os.Getwd()
+	imports := map[string]bool{
+		"context":      true,
+		"encoding/csv": true,
+		"errors":       true,
+		"io":           true,
+		"net/http":     true,
+	}
+	data := templateData{
+		Template: template,
+		Path:     fmt.Sprintf("/%s", path),
+		Tenant:   g.tenant,
+	}
+
+	for _, v := range template.Columns {
+		column := csvColumn{
+			Header: v.NameUnderScore,
+			Format: fmt.Sprintf("fmt.Sprint(v.%s)", v.Name),
+		}
+
+		protobuf := g.types.Protobuf(v.ProtobufType)
+		bits := 64
+		if strings.HasSuffix(protobuf, "32") || protobuf == "float" {
+			bits = 32
+		}
+
+		parse, parsed := "", ""
+		switch {
+		case protobuf == "string":
+			column.Format = fmt.Sprintf("v.%s", v.Name)
+			column.Parse = fmt.Sprintf("\t\t\trecord.%s = value", v.Name)
+		case protobuf == "bytes":
+			column.Format = fmt.Sprintf("base64.StdEncoding.EncodeToString(v.%s)", v.Name)
+			parse, parsed = "base64.StdEncoding.DecodeString(value)", "[]byte"
+			imports["encoding/base64"] = true
+		case protobuf == "bool":
+			parse, parsed = "strconv.ParseBool(value)", "bool"
+		case protobuf == "double" || protobuf == "float":
+			parse, parsed = fmt.Sprintf("strconv.ParseFloat(value, %d)", bits), "float64"
+		case strings.HasPrefix(protobuf, "uint") || strings.HasPrefix(protobuf, "fixed"):
+			parse, parsed = fmt.Sprintf("strconv.ParseUint(value, 10, %d)", bits), "uint64"
+		default:
+			parse, parsed = fmt.Sprintf("strconv.ParseInt(value, 10, %d)", bits), "int64"
+		}
+
+		if parse != "" {
+			imports["fmt"] = true
+			if !strings.HasPrefix(parse, "base64") {
+				imports["strconv"] = true
+			}
+
+			// record is protobuf message, convert to protobuf field type, model type can be overridden in configs/bima.yaml
+			assign := "parsed"
+			if golang := protobufGolang(protobuf); golang != parsed {
+				assign = fmt.Sprintf("%s(parsed)", golang)
+			}
+
+			column.Parse = fmt.Sprintf(`			parsed, err := %s
+			if err != nil {
+				return "", fmt.Errorf("invalid %s value: %%s", value)
+			}
+
+			record.%s = %s`, parse, v.NameUnderScore, v.Name, assign)
+		}
+
+		data.Fields = append(data.Fields, column)
+	}
+
+	for k := range imports {
+		data.Imports = append(data.Imports, k)
+	}
+
+	sort.Strings(data.Imports)
+
+	render(fmt.Sprintf("%s/csv.go", modulePath), csvRoute, data)
+
+	patch(fmt.Sprintf("%s/dic.go", modulePath), func(codeblock string) string {
+		return strings.Replace(codeblock, "var Dic = []dingo.Def{", fmt.Sprintf(`var Dic = []dingo.Def{
+	{
+		Name:  "bima:route:%s:export",
+		Scope: bima.Application,
+		Build: (*Export)(nil),
+		Params: dingo.Params{
+			"Module": dingo.Service("module:%s"),
+		},
+	},
+	{
+		Name:  "bima:route:%s:import",
+		Scope: bima.Application,
+		Build: (*Import)(nil),
+		Params: dingo.Params{
+			"Module": dingo.Service("module:%s"),
+		},
+	},`, template.ModuleLowercase, template.ModuleLowercase, template.ModuleLowercase, template.ModuleLowercase), 1)
+	})
+
+	enlist(workDir, "routes", fmt.Sprintf("%s:export", template.ModuleLowercase))
+	enlist(workDir, "routes", fmt.Sprintf("%s:import", template.ModuleLowercase))
+}
+
+func protobufGolang(protobuf string) string {
+	switch protobuf {
+	case "double":
+		return "float64"
+	case "float":
+		return "float32"
+	case "int32", "sint32", "sfixed32":
+		return "int32"
+	case "uint32", "fixed32":
+		return "uint32"
+	case "uint64", "fixed64":
+		return "uint64"
+	case "bool":
+		return "bool"
+	case "bytes":
+		return "[]byte"
+	case "string":
+		return "string"
+	}
+
+	return "int64"
+}
+
+func (g *encryption) Generate(template generators.Template, modulePath string, driver string) {
+	if len(g.fields) == 0 || driver == "mongo" {
+		return
+	}
+
+	data := templateData{Template: template}
+	for _, v := range template.Columns {
+		if g.fields[v.NameUnderScore] {
+			data.Encrypted = append(data.Encrypted, v.Name)
+		}
+	}
+
+	render(fmt.Sprintf("%s/encryption.go", modulePath), encryptedField, data)
+
+	patch(fmt.Sprintf("%s/model.go", modulePath), func(codeblock string) string {
+		for _, v := range template.Columns {
+			if !g.fields[v.NameUnderScore] {
+				continue
+			}
+
+			tag := `gorm:"type:text"`
+			if v.IsRequired {
+				tag = fmt.Sprintf(`%s validate:"required"`, tag)
+			}
+
+			regex := regexp.MustCompile(fmt.Sprintf("(?m)^(\\s*%s)\\s+%s[ \\t]*(`[^`]*`)?[ \\t]*$", v.Name, regexp.QuoteMeta(v.GolangType)))
+			codeblock = regex.ReplaceAllString(codeblock, fmt.Sprintf("${1} Encrypted `%s`", tag))
+		}
+
+		return codeblock
+	})
+
+	regex := regexp.MustCompile(`(?m)^([ \t]*)(metadata := .*\.Paginate\(.*, &records\)\n)`)
+	for _, file := range []string{"module.go", "trash.go", "csv.go"} {
+		path := fmt.Sprintf("%s/%s", modulePath, file)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		patch(path, func(codeblock string) string {
+			return regex.ReplaceAllString(codeblock, "${1}${2}${1}reveal(records)\n")
+		})
+	}
+}
+
+func (g *trash) Generate(template generators.Template, modulePath string, driver string) {
+	if g.enabled == nil || driver == "mongo" {
+		return
+	}
+
+	if !*g.enabled {
+		patch(fmt.Sprintf("%s/model.go", modulePath), func(codeblock string) string {
+			regex := regexp.MustCompile(`(IsSoftDelete\(\) bool {\s*return )true`)
+
+			return regex.ReplaceAllString(codeblock, "${1}false")
+		})
+
+		return
+	}
+
+	workDir, _ := os.Getwd()
+	data := templateData{
+		Template:   template,
+		Connection: g.connection,
+		Base:       basePath(template, g.path),
+		Tenant:     g.tenant,
+	}
+
+	render(fmt.Sprintf("%s/trash.go", modulePath), trashHandler, data)
+
+	patch(protoFile(template), func(codeblock string) string {
+		end := strings.LastIndex(codeblock, "}")
+		if end == -1 {
+			return codeblock
+		}
+
+		return fmt.Sprintf("%s%s%s", codeblock[:end], execute(trashService, data), codeblock[end:])
+	})
+
+	patch(fmt.Sprintf("%s/dic.go", modulePath), func(codeblock string) string {
+		return strings.Replace(codeblock, "var Dic = []dingo.Def{", fmt.Sprintf(`var Dic = []dingo.Def{
+	{
+		Name:  "bima:listener:%s:trash",
+		Scope: bima.Application,
+		Build: (*Trash)(nil),
+	},`, template.ModuleLowercase), 1)
+	})
+
+	enlist(workDir, "listeners", fmt.Sprintf("%s:trash", template.ModuleLowercase))
+}
+
+func (g *tenancy) Generate(template generators.Template, modulePath string, driver string) {
+	if !g.enabled || driver == "mongo" {
+		return
+	}
+
+	workDir, _ := os.Getwd()
+	render(fmt.Sprintf("%s/tenant.go", modulePath), tenantScope, templateData{
+		Template: template,
+		Base:     basePath(template, g.path),
+	})
+
+	patch(fmt.Sprintf("%s/model.go", modulePath), func(codeblock string) string {
+		return strings.Replace(codeblock, "\t*bima.GormModel\n", "\t*bima.GormModel\n\n\tTenantId string `gorm:\"size:64;index\"`\n", 1)
+	})
+
+	patch(fmt.Sprintf("%s/module.go", modulePath), func(codeblock string) string {
+		// shared module paginator is used by every request, list with own paginator so tenant filter never leak
+		paginated := regexp.MustCompile(`(?s)func \(m \*Module\) GetPaginated\(.*?\n}\n`)
+		codeblock = paginated.ReplaceAllStringFunc(codeblock, func(function string) string {
+			function = strings.ReplaceAll(function, "m.Paginator()", "paginator")
+
+			return strings.Replace(function, "reqeust := paginations.Request{}\n", "reqeust := paginations.Request{}\n\tpaginator := &paginations.Pagination{}\n", 1)
+		})
+
+		notFound := `	if %s.TenantId != tenant {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Data with ID '%%s' not found.", r.Id))
+	}
+
+`
+		replacements := []struct {
+			pattern string
+			replace string
+		}{
+			{
+				pattern: `(?m)^([ \t]*)(ctx = context\.WithValue\(ctx, "scope", "[^"]*"\)\n)`,
+				replace: `${1}${2}	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+`,
+			},
+			{
+				pattern: `(?m)^([ \t]*)(records := make\(\[\]\*grpcs\.)`,
+				replace: `	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	paginator.Filters = append(paginator.Filters, paginations.Filter{Field: tenantFilter, Value: tenant})
+
+${1}${2}`,
+			},
+			{
+				pattern: `(?m)^([ \t]*)(if err := m\.Handler\(\)\.Create\(v\); err != nil {)`,
+				replace: "\tv.TenantId = tenant\n${1}${2}",
+			},
+			{
+				pattern: `(?m)^([ \t]*)(v\.Id = r\.Id\n)`,
+				replace: fmt.Sprintf(notFound, "hold") + "\tv.TenantId = tenant\n${1}${2}",
+			},
+			{
+				pattern: `(?m)^([ \t]*)(copier\.Copy\(r, &v\)\n)`,
+				replace: fmt.Sprintf(notFound, "v") + "${1}${2}",
+			},
+			{
+				pattern: `(?m)^([ \t]*)(m\.Handler\(\)\.Delete\(v, r\.Id\))`,
+				replace: fmt.Sprintf(notFound, "v") + "${1}${2}",
+			},
+			{
+				pattern: `m\.Cache\(\)\.(Get|Set|Invalidate)\(r\.Id`,
+				replace: "m.Cache().${1}(scoped(tenant, r.Id)",
+			},
+		}
+
+		for _, v := range replacements {
+			codeblock = regexp.MustCompile(v.pattern).ReplaceAllString(codeblock, v.replace)
+		}
+
+		return codeblock
+	})
+
+	patch(fmt.Sprintf("%s/dic.go", modulePath), func(codeblock string) string {
+		return strings.Replace(codeblock, "var Dic = []dingo.Def{", fmt.Sprintf(`var Dic = []dingo.Def{
+	{
+		Name:  "bima:middleware:%s:tenant",
+		Scope: bima.Application,
+		Build: (*Tenant)(nil),
+	},
+	{
+		Name:  "bima:listener:%s:tenant",
+		Scope: bima.Application,
+		Build: (*TenantScope)(nil),
+	},`, template.ModuleLowercase, template.ModuleLowercase), 1)
+	})
+
+	enlist(workDir, "middlewares", fmt.Sprintf("%s:tenant", template.ModuleLowercase))
+	enlist(workDir, "listeners", fmt.Sprintf("%s:tenant", template.ModuleLowercase))
+}
+
+func enlist(workDir string, config string, name string) {
+	path := fmt.Sprintf("%s/configs/%s.yaml", workDir, config)
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		panic(err)
+	}
+
+	list, err := configList(content, config)
+	if err != nil {
+		panic(fmt.Errorf("configs/%s.yaml: %s", config, err.Error()))
+	}
+
+	for _, v := range list {
+		if v == name {
+			return
+		}
+	}
+
+	lines := []string{}
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+
+	index := configKey(lines, config)
+	if index == -1 && list != nil {
+		panic(fmt.Errorf("configs/%s.yaml: %s must be a block list", config, config))
+	}
+
+	if index == -1 {
+		lines = append(lines, fmt.Sprintf("%s:", config))
+		index = len(lines) - 1
+	}
+
+	lines[index] = strings.TrimRight(regexp.MustCompile(`:\s*\[\s*\]`).ReplaceAllString(lines[index], ":"), " ")
+	last, indent := index, "    "
+	for _, k := range configItems(lines, index) {
+		last = k
+		indent = lines[k][:len(lines[k])-len(strings.TrimLeft(lines[k], " \t"))]
+	}
+
+	lines = append(lines[:last+1], append([]string{fmt.Sprintf("%s- %s", indent, name)}, lines[last+1:]...)...)
+
+	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func listed(workDir string, config string, name string) bool {
+	content, err := os.ReadFile(fmt.Sprintf("%s/configs/%s.yaml", workDir, config))
+	if err != nil {
+		return false
+	}
+
+	list, err := configList(content, config)
+	if err != nil {
+		panic(fmt.Errorf("configs/%s.yaml: %s", config, err.Error()))
+	}
+
+	for _, v := range list {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func delist(workDir string, config string, name string) {
+	path := fmt.Sprintf("%s/configs/%s.yaml", workDir, config)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	if _, err = configList(content, config); err != nil {
+		panic(fmt.Errorf("configs/%s.yaml: %s", config, err.Error()))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	index := configKey(lines, config)
+	if index == -1 {
+		return
+	}
+
+	items := configItems(lines, index)
+	removed := map[int]bool{}
+	for _, k := range items {
+		item := []string{}
+		if yaml.Unmarshal([]byte(strings.TrimSpace(lines[k])), &item) == nil && len(item) == 1 && item[0] == name {
+			removed[k] = true
+		}
+	}
+
+	if len(removed) == 0 {
+		return
+	}
+
+	result := make([]string, 0, len(lines))
+	for k, v := range lines {
+		if !removed[k] {
+			result = append(result, v)
+		}
+	}
+
+	if len(removed) == len(items) {
+		result[index] = strings.Replace(result[index], fmt.Sprintf("%s:", config), fmt.Sprintf("%s: []", config), 1)
+	}
+
+	err = os.WriteFile(path, []byte(strings.Join(result, "\n")+"\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func configList(content []byte, config string) ([]string, error) {
+	document := yaml.MapSlice{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	value := yamlLookup(document, config)
+	if value == nil {
+		return nil, nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", config)
+	}
+
+	list := make([]string, 0, len(items))
+	for _, v := range items {
+		list = append(list, fmt.Sprint(v))
+	}
+
+	return list, nil
+}
+
+func configKey(lines []string, config string) int {
+	regex := regexp.MustCompile(fmt.Sprintf(`^%s:\s*(\[\s*\])?\s*(#.*)?$`, regexp.QuoteMeta(config)))
+	for k, v := range lines {
+		if regex.MatchString(v) {
+			return k
+		}
+	}
+
+	return -1
+}
+
+func configItems(lines []string, index int) []int {
+	items := []int{}
+	for k := index + 1; k < len(lines); k++ {
+		trimmed := strings.TrimSpace(lines[k])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(trimmed, "-") {
+			break
+		}
+
+		items = append(items, k)
+	}
+
+	return items
+}
+
+func basePath(template generators.Template, path string) string {
+	if path == "" {
+		path = template.ModulePluralLowercase
+	}
+
+	return fmt.Sprintf("%s/%s", template.ApiPrefix, path)
+}
+
+func provide(codeblock string, imported string, register string) string {
+	contents := strings.Split(codeblock, "\n")
+	importIdx := -1
+	moduleIdx := -1
+	for k, v := range contents {
+		if strings.Contains(v, generators.ModuleImport) {
+			importIdx = k
+
+			continue
+		}
+
+		if strings.Contains(v, generators.ModuleRegister) {
+			moduleIdx = k
+
+			break
+		}
+	}
+
+	if moduleIdx == -1 {
+		return codeblock
+	}
+
+	if importIdx != -1 && !strings.Contains(codeblock, imported) {
+		contents[importIdx] = fmt.Sprintf(`    //%s
+    %s`, generators.ModuleImport, imported)
+	}
+
+	contents[moduleIdx] = fmt.Sprintf(`
+    %s
+    //%s`, register, generators.ModuleRegister)
+
+	return strings.Join(contents, "\n")
+}
+
+func render(path string, text string, data interface{}) {
+	template, err := engine.New(path).Parse(text)
+	if err != nil {
+		panic(err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	err = template.Execute(file, data)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func execute(text string, data interface{}) string {
+	var buffer strings.Builder
+	err := engine.Must(engine.New("codeblock").Parse(text)).Execute(&buffer, data)
+	if err != nil {
+		panic(err)
+	}
+
+	return buffer.String()
+}
+
+func protoFile(template generators.Template) string {
+	workDir, _ := os.Getwd()
+
+	var path strings.Builder
+	path.WriteString(workDir)
+	path.WriteString("/protos/")
+	path.WriteString(template.ModuleLowercase)
+	path.WriteString(".proto")
+
+	return path.String()
+}
+
+func patch(path string, modify func(codeblock string) string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+
+	err = os.WriteFile(path, []byte(modify(string(content))), 0644)
+	if err != nil {
+		panic(err)
+	}
+}
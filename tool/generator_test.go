@@ -0,0 +1,176 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestEnlist(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected string
+		panic    bool
+	}{
+		{
+			name:     "missing file",
+			expected: "routes:\n    - todo:export\n",
+		},
+		{
+			name:     "keep comments and order",
+			content:  "# enabled routes\nroutes:\n    # health check\n    - health\n    - order:import # csv\n\nother: value\n",
+			expected: "# enabled routes\nroutes:\n    # health check\n    - health\n    - order:import # csv\n    - todo:export\n\nother: value\n",
+		},
+		{
+			name:     "keep indentation",
+			content:  "routes:\n- health\n",
+			expected: "routes:\n- health\n- todo:export\n",
+		},
+		{
+			name:     "empty flow list",
+			content:  "routes: []\n",
+			expected: "routes:\n    - todo:export\n",
+		},
+		{
+			name:     "missing key",
+			content:  "# routes\nother:\n    - value\n",
+			expected: "# routes\nother:\n    - value\nroutes:\n    - todo:export\n",
+		},
+		{
+			name:     "already listed",
+			content:  "routes:\n    # keep\n    - 'todo:export'\n",
+			expected: "routes:\n    # keep\n    - 'todo:export'\n",
+		},
+		{
+			name:    "invalid yaml",
+			content: "routes:\n  - health\n bad\n",
+			panic:   true,
+		},
+		{
+			name:    "not a list",
+			content: "routes: health\n",
+			panic:   true,
+		},
+		{
+			name:    "flow list",
+			content: "routes: [health]\n",
+			panic:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			workDir := configDir(t, "routes", c.content)
+			defer func() {
+				if r := recover(); (r != nil) != c.panic {
+					t.Errorf("expected panic %t, got %v", c.panic, r)
+				}
+			}()
+
+			enlist(workDir, "routes", "todo:export")
+
+			content, _ := os.ReadFile(fmt.Sprintf("%s/configs/routes.yaml", workDir))
+			if string(content) != c.expected {
+				t.Errorf("expected\n%s\ngot\n%s", c.expected, content)
+			}
+		})
+	}
+}
+
+func TestDelist(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected string
+		panic    bool
+	}{
+		{
+			name:     "keep comments and order",
+			content:  "# enabled routes\nroutes:\n    - health # probe\n    - todo:export\n    # csv import\n    - todo:import\n\nother: value\n",
+			expected: "# enabled routes\nroutes:\n    - health # probe\n    # csv import\n    - todo:import\n\nother: value\n",
+		},
+		{
+			name:     "quoted with comment",
+			content:  "routes:\n    - \"todo:export\" # csv\n    - health\n",
+			expected: "routes:\n    - health\n",
+		},
+		{
+			name:     "last item",
+			content:  "routes:\n    - todo:export\nother: value\n",
+			expected: "routes: []\nother: value\n",
+		},
+		{
+			name:     "not listed",
+			content:  "routes:\n    - health\n",
+			expected: "routes:\n    - health\n",
+		},
+		{
+			name:    "invalid yaml",
+			content: "routes: [health\n",
+			panic:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			workDir := configDir(t, "routes", c.content)
+			defer func() {
+				if r := recover(); (r != nil) != c.panic {
+					t.Errorf("expected panic %t, got %v", c.panic, r)
+				}
+			}()
+
+			delist(workDir, "routes", "todo:export")
+
+			content, _ := os.ReadFile(fmt.Sprintf("%s/configs/routes.yaml", workDir))
+			if string(content) != c.expected {
+				t.Errorf("expected\n%s\ngot\n%s", c.expected, content)
+			}
+		})
+	}
+}
+
+func configDir(t *testing.T, config string, content string) string {
+	workDir := t.TempDir()
+	if err := os.Mkdir(fmt.Sprintf("%s/configs", workDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if content == "" {
+		return workDir
+	}
+
+	if err := os.WriteFile(fmt.Sprintf("%s/configs/%s.yaml", workDir, config), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return workDir
+}
+
+func TestProtobufGolang(t *testing.T) {
+	cases := map[string]string{
+		"double":   "float64",
+		"float":    "float32",
+		"int32":    "int32",
+		"sint32":   "int32",
+		"sfixed32": "int32",
+		"uint32":   "uint32",
+		"fixed32":  "uint32",
+		"int64":    "int64",
+		"sint64":   "int64",
+		"uint64":   "uint64",
+		"fixed64":  "uint64",
+		"bool":     "bool",
+		"bytes":    "[]byte",
+		"string":   "string",
+	}
+
+	for protobuf, expected := range cases {
+		t.Run(protobuf, func(t *testing.T) {
+			if result := protobufGolang(protobuf); result != expected {
+				t.Errorf("expected %s, got %s", expected, result)
+			}
+		})
+	}
+}
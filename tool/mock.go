@@ -0,0 +1,348 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bimalabs/framework/v4/configs"
+)
+
+type (
+	Mock string
+
+	mockRoute struct {
+		endpoint
+		pattern     *regexp.Regexp
+		params      []string
+		definitions map[string]*schema
+	}
+
+	mockServer struct {
+		routes []mockRoute
+	}
+
+	mockError struct {
+		Code    int           `json:"code"`
+		Message string        `json:"message"`
+		Details []interface{} `json:"details"`
+	}
+)
+
+const (
+	codeInvalidArgument = 3
+	codeNotFound        = 5
+	codeUnimplemented   = 12 // grpc-gateway send it with 405 Method Not Allowed
+)
+
+func (m Mock) Run(port int) error {
+	workDir, _ := os.Getwd()
+	modules, err := swaggers(workDir)
+	if err != nil {
+		return err
+	}
+
+	if port == 0 {
+		env := configs.Env{}
+		config(&env, string(m), filepath.Ext(string(m)))
+		port = env.HttpPort
+	}
+
+	if port == 0 {
+		port = 7777
+	}
+
+	server := newMockServer(modules)
+	fmt.Printf("Mock server with %d route(s) listening on http://localhost:%d\n", len(server.routes), port)
+
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), server)
+}
+
+func newMockServer(modules []swaggerModule) mockServer {
+	server := mockServer{}
+	for _, module := range modules {
+		for _, e := range module.Spec.endpoints() {
+			route := mockRoute{endpoint: e, definitions: module.Spec.Definitions}
+			pattern := pathParamRegex.ReplaceAllStringFunc(e.Path, func(param string) string {
+				route.params = append(route.params, pathParamRegex.FindStringSubmatch(param)[1])
+
+				return "\x00"
+			})
+
+			pattern = strings.ReplaceAll(regexp.QuoteMeta(pattern), "\x00", "([^/]+)")
+			route.pattern = regexp.MustCompile(fmt.Sprintf("^%s$", pattern))
+			server.routes = append(server.routes, route)
+		}
+	}
+
+	sort.SliceStable(server.routes, func(i, j int) bool {
+		return len(server.routes[i].params) < len(server.routes[j].params)
+	})
+
+	return server
+}
+
+func (s mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	status := s.handle(w, r)
+	fmt.Printf("%s %s %d\n", r.Method, r.URL.Path, status)
+}
+
+func (s mockServer) handle(w http.ResponseWriter, r *http.Request) int {
+	allowed := []string{}
+	for _, route := range s.routes {
+		match := route.pattern.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			continue
+		}
+
+		if !strings.EqualFold(route.Method, r.Method) {
+			allowed = append(allowed, strings.ToUpper(route.Method))
+
+			continue
+		}
+
+		params := map[string]string{}
+		for k, v := range route.params {
+			params[v] = match[k+1]
+		}
+
+		return route.serve(w, r, params)
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+
+		return reply(w, http.StatusMethodNotAllowed, mockError{Code: codeUnimplemented, Message: http.StatusText(http.StatusMethodNotAllowed), Details: []interface{}{}})
+	}
+
+	return reply(w, http.StatusNotFound, mockError{Code: codeNotFound, Message: http.StatusText(http.StatusNotFound), Details: []interface{}{}})
+}
+
+func (r mockRoute) serve(w http.ResponseWriter, request *http.Request, params map[string]string) int {
+	var body interface{}
+	query := request.URL.Query()
+	for _, p := range r.Operation.Parameters {
+		switch p.In {
+		case "path":
+			if err := r.validate(params[p.Name], p.schema(), p.Name, 0); err != nil {
+				return invalid(w, err)
+			}
+		case "query":
+			values, ok := query[p.Name]
+			if !ok {
+				if p.Required {
+					return invalid(w, fmt.Errorf("missing parameter %s", p.Name))
+				}
+
+				continue
+			}
+
+			s := p.schema()
+			if s.Type == "array" {
+				s = s.Items
+			}
+
+			for _, v := range values {
+				if err := r.validate(v, s, p.Name, 0); err != nil {
+					return invalid(w, err)
+				}
+			}
+		case "body":
+			decoder := json.NewDecoder(request.Body)
+			decoder.UseNumber()
+			if err := decoder.Decode(&body); err != nil {
+				return invalid(w, fmt.Errorf("invalid request body: %s", err.Error()))
+			}
+
+			if err := r.validate(body, p.Schema, "", 0); err != nil {
+				return invalid(w, err)
+			}
+		}
+	}
+
+	response, ok := r.Operation.Responses["200"]
+	if !ok {
+		return reply(w, http.StatusOK, map[string]interface{}{})
+	}
+
+	result := example(response.Schema, r.definitions, 0)
+	if object, ok := result.(map[string]interface{}); ok {
+		if fields, ok := body.(map[string]interface{}); ok {
+			for k, v := range fields {
+				if _, exists := object[k]; exists {
+					object[k] = v
+				}
+			}
+		}
+
+		for k, v := range params {
+			if _, exists := object[k]; exists {
+				object[k] = v
+			}
+		}
+	}
+
+	return reply(w, http.StatusOK, result)
+}
+
+func (r mockRoute) validate(value interface{}, s *schema, field string, depth int) error {
+	if s == nil || value == nil || depth > 10 {
+		return nil
+	}
+
+	if s.Ref != "" {
+		return r.validate(value, r.definitions[refName(s.Ref)], field, depth+1)
+	}
+
+	switch s.Type {
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			if number, ok := value.(json.Number); ok && (s.Format == "int64" || s.Format == "uint64") {
+				text = number.String()
+			} else {
+				return fmt.Errorf("invalid value for %s, expected string", label(field))
+			}
+		}
+
+		if s.Format == "int64" || s.Format == "uint64" {
+			if _, err := strconv.ParseInt(text, 10, 64); err != nil {
+				if _, err := strconv.ParseUint(text, 10, 64); err != nil {
+					return fmt.Errorf("invalid value for %s, expected integer", label(field))
+				}
+			}
+		}
+
+		if s.MaxLength > 0 && utf8.RuneCountInString(text) > s.MaxLength {
+			return fmt.Errorf("%s must be at most %d characters", label(field), s.MaxLength)
+		}
+
+		if len(s.Enum) > 0 {
+			for _, v := range s.Enum {
+				if v == text {
+					return nil
+				}
+			}
+
+			return fmt.Errorf("invalid value for %s, expected one of %s", label(field), strings.Join(s.Enum, ", "))
+		}
+	case "integer", "number":
+		text := fmt.Sprint(value)
+		if _, ok := value.(bool); ok {
+			return fmt.Errorf("invalid value for %s, expected %s", label(field), s.Type)
+		}
+
+		if s.Type == "integer" {
+			if _, err := strconv.ParseInt(text, 10, 64); err != nil {
+				return fmt.Errorf("invalid value for %s, expected integer", label(field))
+			}
+
+			return nil
+		}
+
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return fmt.Errorf("invalid value for %s, expected number", label(field))
+		}
+	case "boolean":
+		if text, ok := value.(string); ok {
+			if _, err := strconv.ParseBool(text); err != nil {
+				return fmt.Errorf("invalid value for %s, expected boolean", label(field))
+			}
+
+			return nil
+		}
+
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("invalid value for %s, expected boolean", label(field))
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value for %s, expected array", label(field))
+		}
+
+		for k, v := range items {
+			if err := r.validate(v, s.Items, fmt.Sprintf("%s[%d]", field, k), depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value for %s, expected object", label(field))
+		}
+
+		for _, k := range s.Required {
+			if _, ok := object[k]; !ok {
+				return fmt.Errorf("%s is required", label(fieldPath(field, k)))
+			}
+		}
+
+		keys := make([]string, 0, len(object))
+		for k := range object {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			property, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties != nil {
+					continue
+				}
+
+				return fmt.Errorf("unknown field %q", fieldPath(field, k))
+			}
+
+			if err := r.validate(object[k], property, fieldPath(field, k), depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func fieldPath(parent string, field string) string {
+	if parent == "" {
+		return field
+	}
+
+	return fmt.Sprintf("%s.%s", parent, field)
+}
+
+func label(field string) string {
+	if field == "" {
+		return "request body"
+	}
+
+	return fmt.Sprintf("field %q", field)
+}
+
+func invalid(w http.ResponseWriter, err error) int {
+	return reply(w, http.StatusBadRequest, mockError{Code: codeInvalidArgument, Message: err.Error(), Details: []interface{}{}})
+}
+
+func reply(w http.ResponseWriter, status int, body interface{}) int {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+
+	return status
+}
@@ -0,0 +1,165 @@
+package tool
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMockValidate(t *testing.T) {
+	route := mockRoute{definitions: map[string]*schema{
+		"v1Todo": {
+			Type:     "object",
+			Required: []string{"task"},
+			Properties: map[string]*schema{
+				"task":     {Type: "string", MaxLength: 5},
+				"status":   {Type: "string", Enum: []string{"open", "done"}},
+				"priority": {Type: "integer"},
+				"score":    {Type: "number"},
+				"done":     {Type: "boolean"},
+				"counter":  {Type: "string", Format: "int64"},
+				"tags":     {Type: "array", Items: &schema{Type: "string"}},
+				"labels":   {Type: "object", AdditionalProperties: &schema{Type: "string"}},
+			},
+		},
+	}}
+
+	cases := []struct {
+		name  string
+		body  string
+		error string
+	}{
+		{name: "valid", body: `{"task":"write","status":"open","priority":1,"score":1.5,"done":true,"counter":"10","tags":["a"],"labels":{"any":"value"}}`},
+		{name: "int64 as number", body: `{"task":"write","counter":10}`},
+		{name: "missing required", body: `{"status":"open"}`, error: `field "task" is required`},
+		{name: "unknown field", body: `{"task":"write","owner":"me"}`, error: `unknown field "owner"`},
+		{name: "max length", body: `{"task":"writes"}`, error: `field "task" must be at most 5 characters`},
+		{name: "unknown enum", body: `{"task":"write","status":"closed"}`, error: `invalid value for field "status", expected one of open, done`},
+		{name: "invalid integer", body: `{"task":"write","priority":1.5}`, error: `invalid value for field "priority", expected integer`},
+		{name: "boolean as number", body: `{"task":"write","score":true}`, error: `invalid value for field "score", expected number`},
+		{name: "invalid boolean", body: `{"task":"write","done":"yes"}`, error: `invalid value for field "done", expected boolean`},
+		{name: "invalid int64", body: `{"task":"write","counter":"ten"}`, error: `invalid value for field "counter", expected integer`},
+		{name: "invalid array item", body: `{"task":"write","tags":["a",1]}`, error: `invalid value for field "tags[1]", expected string`},
+		{name: "invalid body", body: `[]`, error: `invalid value for request body, expected object`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(c.body))
+			decoder.UseNumber()
+
+			var body interface{}
+			if err := decoder.Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			err := route.validate(body, &schema{Ref: "#/definitions/v1Todo"}, "", 0)
+			if c.error == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %s", err.Error())
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != c.error {
+				t.Errorf("expected %q, got %v", c.error, err)
+			}
+		})
+	}
+}
+
+func TestMockServer(t *testing.T) {
+	server := newMockServer([]swaggerModule{{
+		Name: "Todo",
+		File: "todo",
+		Spec: swagger{
+			Paths: map[string]map[string]*operation{
+				"/api/v1/todos": {
+					"get": {
+						OperationId: "Todos_GetPaginated",
+						Parameters:  []parameter{{Name: "page", In: "query", Type: "integer"}},
+					},
+					"post": {
+						OperationId: "Todos_Create",
+						Parameters:  []parameter{{Name: "body", In: "body", Required: true, Schema: &schema{Ref: "#/definitions/v1Todo"}}},
+						Responses:   map[string]response{"200": {Schema: &schema{Ref: "#/definitions/v1Todo"}}},
+					},
+				},
+				"/api/v1/todos/{id}": {
+					"get": {
+						OperationId: "Todos_Get",
+						Parameters:  []parameter{{Name: "id", In: "path", Required: true, Type: "string"}},
+						Responses:   map[string]response{"200": {Schema: &schema{Ref: "#/definitions/v1Todo"}}},
+					},
+					"delete": {
+						OperationId: "Todos_Delete",
+						Parameters:  []parameter{{Name: "id", In: "path", Required: true, Type: "string"}},
+					},
+				},
+			},
+			Definitions: map[string]*schema{
+				"v1Todo": {Type: "object", Properties: map[string]*schema{
+					"id":   {Type: "string"},
+					"task": {Type: "string"},
+				}},
+			},
+		},
+	}})
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   int
+		allow  string
+		result string
+	}{
+		{name: "get", method: http.MethodGet, path: "/api/v1/todos/abc", status: http.StatusOK, result: `{"id":"abc","task":"string"}`},
+		{name: "create", method: http.MethodPost, path: "/api/v1/todos", body: `{"task":"write"}`, status: http.StatusOK, result: `{"id":"string","task":"write"}`},
+		{name: "invalid body", method: http.MethodPost, path: "/api/v1/todos", body: `{"owner":"me"}`, status: http.StatusBadRequest, code: codeInvalidArgument},
+		{name: "invalid query", method: http.MethodGet, path: "/api/v1/todos?page=first", status: http.StatusBadRequest, code: codeInvalidArgument},
+		{name: "method mismatch", method: http.MethodPut, path: "/api/v1/todos", status: http.StatusMethodNotAllowed, code: codeUnimplemented, allow: "GET, POST"},
+		{name: "path method mismatch", method: http.MethodPatch, path: "/api/v1/todos/abc", status: http.StatusMethodNotAllowed, code: codeUnimplemented, allow: "GET, DELETE"},
+		{name: "unknown path", method: http.MethodGet, path: "/api/v1/users", status: http.StatusNotFound, code: codeNotFound},
+		{name: "preflight", method: http.MethodOptions, path: "/api/v1/todos", status: http.StatusNoContent},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(c.method, c.path, strings.NewReader(c.body)))
+
+			if recorder.Code != c.status {
+				t.Fatalf("expected status %d, got %d: %s", c.status, recorder.Code, recorder.Body.String())
+			}
+
+			if allow := recorder.Header().Get("Allow"); allow != c.allow {
+				t.Errorf("expected Allow %q, got %q", c.allow, allow)
+			}
+
+			if c.result != "" {
+				if result := strings.TrimSpace(recorder.Body.String()); result != c.result {
+					t.Errorf("expected %s, got %s", c.result, result)
+				}
+			}
+
+			if c.code == 0 {
+				return
+			}
+
+			failure := mockError{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &failure); err != nil {
+				t.Fatal(err)
+			}
+
+			if failure.Code != c.code {
+				t.Errorf("expected code %d, got %d", c.code, failure.Code)
+			}
+		})
+	}
+}
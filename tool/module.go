@@ -1,23 +1,17 @@
 package tool
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
-	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/bimalabs/framework/v4/configs"
-	"github.com/bimalabs/framework/v4/utils"
 	"github.com/bimalabs/generators"
 	"github.com/fatih/color"
-	"github.com/gertd/go-pluralize"
 	"github.com/iancoleman/strcase"
 	"github.com/jinzhu/copier"
 	"github.com/vito/go-interact/interact"
@@ -31,13 +25,41 @@ const c = "configs/modules.yaml"
 
 type (
 	module struct {
-		Config []string `yaml:"modules"`
+		Config      []string              `yaml:"modules"`
+		Definitions map[string]definition `yaml:"definitions,omitempty"`
+	}
+
+	definition struct {
+		Name         string                `yaml:"name"`
+		Plural       string                `yaml:"plural"`
+		Permissions  map[string][]string   `yaml:"permissions,omitempty"`
+		Queries      map[string]fieldQuery `yaml:"queries,omitempty"`
+		Encrypted    []string              `yaml:"encrypted,omitempty"`
+		ModuleOption `yaml:",inline"`
+	}
+
+	fieldQuery struct {
+		Operators  []string `yaml:"operators,omitempty"`
+		Sortable   bool     `yaml:"sortable,omitempty"`
+		Searchable bool     `yaml:"searchable,omitempty"`
+	}
+
+	ModuleOption struct {
+		Prefix        string `yaml:"prefix,omitempty"`
+		Path          string `yaml:"path,omitempty"`
+		Connection    string `yaml:"connection,omitempty"`
+		Authorization bool   `yaml:"authorization,omitempty"`
+		Events        bool   `yaml:"events,omitempty"`
+		Batch         bool   `yaml:"batch,omitempty"`
+		Csv           bool   `yaml:"csv,omitempty"`
+		Tenant        bool   `yaml:"tenant_scoped,omitempty"`
+		SoftDelete    *bool  `yaml:"soft_delete,omitempty"`
 	}
 
 	Module string
 )
 
-func (m Module) Create(file string) error {
+func (m Module) Create(file string, option ModuleOption) error {
 	if err := Call("dump"); err != nil {
 		color.New(color.FgRed).Println("Error updating services container")
 
@@ -47,10 +69,21 @@ func (m Module) Create(file string) error {
 	env := configs.Env{}
 	config(&env, file, filepath.Ext(file))
 
-	generator := NewGenerator(env.Db.Driver, env.ApiPrefix)
+	option, ask, err := m.prepare(file, env, option)
+	if err != nil {
+		color.New(color.FgRed).Println(err.Error())
+
+		return err
+	}
+
+	workDir, _ := os.Getwd()
+	setting := parseProject(workDir)
+	queries := map[string]fieldQuery{}
+	encrypted := map[string]bool{}
+	generator := NewGenerator(env.Db.Driver, env.ApiPrefix, setting, option, queries, encrypted)
 
 	termColor := color.New(color.FgGreen, color.Bold)
-	err := create(generator, termColor, string(m))
+	err = create(generator, termColor, string(m), setting.types(), option, queries, encrypted, ask)
 	if err != nil {
 		color.New(color.FgRed).Println(err.Error())
 		_ = m.Remove()
@@ -58,30 +91,60 @@ func (m Module) Create(file string) error {
 		return err
 	}
 
-	if err = Call("genproto"); err != nil {
+	return finalize(m)
+}
+
+func (m Module) prepare(file string, env configs.Env, option ModuleOption) (ModuleOption, bool, error) {
+	workDir, _ := os.Getwd()
+	if registered, ok := parseModule(workDir).Definitions[strcase.ToDelimited(string(m), '_')]; ok {
+		option = option.merge(registered.ModuleOption)
+	}
+
+	option = option.normalize()
+	if err := option.validate(file, env.Db.Driver); err != nil {
+		return option, false, err
+	}
+
+	ask := option.SoftDelete == nil && env.Db.Driver != "mongo"
+	if ask {
+		enabled := true
+		option.SoftDelete = &enabled
+	}
+
+	return option, ask, nil
+}
+
+func finalize(modules ...Module) error {
+	rollback := func() {
+		for _, m := range modules {
+			_ = m.Remove()
+		}
+	}
+
+	if err := Call("genproto"); err != nil {
 		color.New(color.FgRed).Println("Error generate codes from proto files")
-		_ = m.Remove()
+		rollback()
 
 		return err
 	}
 
-	if err = Call("clean"); err != nil {
+	if err := Call("clean"); err != nil {
 		color.New(color.FgRed).Println("Error cleaning dependencies")
-		_ = m.Remove()
+		rollback()
 
 		return err
 	}
 
-	if err = Call("dump"); err != nil {
+	if err := Call("dump"); err != nil {
 		color.New(color.FgRed).Println("Error updating services container")
-		_ = m.Remove()
+		rollback()
 
 		return err
 	}
 
-	if err = Call("clean"); err != nil {
+	if err := Call("clean"); err != nil {
 		color.New(color.FgRed).Println("Error cleaning dependencies")
-		_ = m.Remove()
+		rollback()
 
 		return err
 	}
@@ -109,18 +172,22 @@ func (m Module) Remove() error {
 func remove(module string) {
 	util := color.New(color.FgGreen, color.Bold)
 	workDir, _ := os.Getwd()
-	pluralizer := pluralize.NewClient()
+	pluralizer := parseProject(workDir).pluralizer()
 	moduleName := strcase.ToCamel(pluralizer.Singular(module))
 	modulePlural := strcase.ToDelimited(pluralizer.Plural(moduleName), '_')
 	moduleUnderscore := strcase.ToDelimited(module, '_')
-	list := parseModule(workDir)
+	registry := parseModule(workDir)
 
 	exist := false
-	for _, v := range list {
+	list := make([]string, 0, len(registry.Config))
+	for _, v := range registry.Config {
 		if v == fmt.Sprintf("module:%s", moduleUnderscore) {
 			exist = true
-			break
+
+			continue
 		}
+
+		list = append(list, v)
 	}
 
 	if !exist {
@@ -128,43 +195,34 @@ func remove(module string) {
 		return
 	}
 
+	if registered, ok := registry.Definitions[moduleUnderscore]; ok {
+		moduleName = registered.Name
+		modulePlural = registered.Plural
+	}
+
+	delist(workDir, "middlewares", fmt.Sprintf("%s:authorization", moduleUnderscore))
+	delist(workDir, "listeners", fmt.Sprintf("%s:query", moduleUnderscore))
+	delist(workDir, "routes", fmt.Sprintf("%s:export", moduleUnderscore))
+	delist(workDir, "routes", fmt.Sprintf("%s:import", moduleUnderscore))
+	delist(workDir, "middlewares", fmt.Sprintf("%s:tenant", moduleUnderscore))
+	delist(workDir, "listeners", fmt.Sprintf("%s:tenant", moduleUnderscore))
+	delist(workDir, "listeners", fmt.Sprintf("%s:trash", moduleUnderscore))
+
 	mod, err := os.ReadFile(fmt.Sprintf("%s/go.mod", workDir))
 	if err != nil {
 		panic(err)
 	}
 
-	jsonModules := fmt.Sprintf("%s/swaggers/modules.json", workDir)
-	file, _ := os.ReadFile(jsonModules)
-	modulesJson := []generators.ModuleJson{}
-	registered := modulesJson
-	_ = json.Unmarshal(file, &modulesJson)
-	for _, v := range modulesJson {
-		if v.Name != moduleName {
-			mUrl, _ := url.Parse(v.Url)
-			query := mUrl.Query()
-
-			query.Set("v", strconv.Itoa(int(time.Now().UnixMicro())))
-			mUrl.RawQuery = query.Encode()
-			v.Url = mUrl.String()
-			registered = append(registered, v)
-		}
-	}
-
-	registeredByte, _ := json.Marshal(registered)
-	_ = os.WriteFile(jsonModules, registeredByte, 0644)
-
 	packageName := modfile.ModulePath(mod)
-	yaml := fmt.Sprintf("%s/configs/modules.yaml", workDir)
-	file, _ = os.ReadFile(yaml)
-	modules := string(file)
+	registry.Config = list
+	delete(registry.Definitions, moduleUnderscore)
+	_ = registry.save(workDir)
 
 	provider := fmt.Sprintf("%s/configs/provider.go", workDir)
-	file, _ = os.ReadFile(provider)
+	file, _ := os.ReadFile(provider)
 	codeblock := string(file)
 
 	modRegex := regexp.MustCompile(fmt.Sprintf("(?m)[\r\n]+^.*module:%s.*$", moduleUnderscore))
-	modules = modRegex.ReplaceAllString(modules, "")
-	_ = os.WriteFile(yaml, []byte(modules), 0644)
 
 	regex := regexp.MustCompile(fmt.Sprintf("(?m)[\r\n]+^.*%s.*$", fmt.Sprintf("%s/%s", packageName, modulePlural)))
 	codeblock = regex.ReplaceAllString(codeblock, "")
@@ -173,18 +231,21 @@ func remove(module string) {
 	_ = os.WriteFile(provider, []byte(codeblock), 0644)
 
 	os.RemoveAll(fmt.Sprintf("%s/%s", workDir, modulePlural))
+	os.RemoveAll(fmt.Sprintf("%s/clients/%s", workDir, moduleUnderscore))
 	os.Remove(fmt.Sprintf("%s/protos/%s.proto", workDir, moduleUnderscore))
 	os.Remove(fmt.Sprintf("%s/protos/builds/%s_grpc.pb.go", workDir, moduleUnderscore))
 	os.Remove(fmt.Sprintf("%s/protos/builds/%s.pb.go", workDir, moduleUnderscore))
 	os.Remove(fmt.Sprintf("%s/protos/builds/%s.pb.gw.go", workDir, moduleUnderscore))
 	os.Remove(fmt.Sprintf("%s/swaggers/%s.swagger.json", workDir, moduleUnderscore))
 
+	_, _ = rebuild(workDir)
+
 	fmt.Print("Module ")
 	util.Print(module)
 	util.Println(" deleted")
 }
 
-func parseModule(dir string) []string {
+func parseModule(dir string) module {
 	var path strings.Builder
 	path.WriteString(dir)
 	path.WriteString("/")
@@ -195,27 +256,148 @@ func parseModule(dir string) []string {
 	if err != nil {
 		log.Println(err)
 
-		return []string{}
+		return mapping
 	}
 
 	err = yaml.Unmarshal(config, &mapping)
 	if err != nil {
 		log.Println(err)
 
-		return []string{}
+		return module{}
+	}
+
+	return mapping
+}
+
+func (m module) save(dir string) error {
+	var path strings.Builder
+	path.WriteString(dir)
+	path.WriteString("/")
+	path.WriteString(c)
+
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.String(), content, 0644)
+}
+
+func (m *module) register(template generators.Template, option ModuleOption, queries map[string]fieldQuery, encrypted map[string]bool) {
+	if m.Definitions == nil {
+		m.Definitions = map[string]definition{}
+	}
+
+	permissions := m.Definitions[template.ModuleLowercase].Permissions
+	if option.Authorization && len(permissions) == 0 {
+		permissions = map[string][]string{}
+		for _, v := range []string{"list", "get", "create", "update", "delete"} {
+			permissions[v] = []string{"admin"}
+		}
 	}
 
-	return mapping.Config
+	columns := []string{}
+	for _, v := range template.Columns {
+		if encrypted[v.NameUnderScore] {
+			columns = append(columns, v.NameUnderScore)
+		}
+	}
+
+	m.Definitions[template.ModuleLowercase] = definition{
+		Name:         template.Module,
+		Plural:       template.ModulePluralLowercase,
+		Permissions:  permissions,
+		Queries:      queries,
+		Encrypted:    columns,
+		ModuleOption: option,
+	}
 }
 
-func create(factory *generators.Factory, util *color.Color, name string) error {
+func (o ModuleOption) normalize() ModuleOption {
+	if o.Prefix != "" {
+		o.Prefix = "/" + strings.Trim(o.Prefix, "/")
+	}
+
+	o.Path = strings.Trim(o.Path, "/")
+
+	return o
+}
+
+func (o ModuleOption) merge(registered ModuleOption) ModuleOption {
+	if o.Prefix == "" {
+		o.Prefix = registered.Prefix
+	}
+
+	if o.Path == "" {
+		o.Path = registered.Path
+	}
+
+	if o.Connection == "" {
+		o.Connection = registered.Connection
+	}
+
+	o.Authorization = o.Authorization || registered.Authorization
+	o.Events = o.Events || registered.Events
+	o.Batch = o.Batch || registered.Batch
+	o.Csv = o.Csv || registered.Csv
+	o.Tenant = o.Tenant || registered.Tenant
+	if o.SoftDelete == nil {
+		o.SoftDelete = registered.SoftDelete
+	}
+
+	return o
+}
+
+func (o ModuleOption) validate(file string, driver string) error {
+	if o.Batch && driver == "mongo" {
+		return errors.New("batch is only supported for gorm driver")
+	}
+
+	if o.Csv && driver == "mongo" {
+		return errors.New("csv is only supported for gorm driver")
+	}
+
+	if o.Tenant && driver == "mongo" {
+		return errors.New("tenant scope is only supported for gorm driver")
+	}
+
+	if o.Csv && o.Prefix != "" {
+		return errors.New("csv routes are served under API_PREFIX and can not be combined with prefix")
+	}
+
+	if o.Connection == "" {
+		return nil
+	}
+
+	if driver == "mongo" {
+		return errors.New("connection is only supported for gorm driver")
+	}
+
+	for _, v := range connections(file) {
+		if v == o.Connection {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("connection %s is not declared in %s", o.Connection, file)
+}
+
+func create(factory *generators.Factory, util *color.Color, name string, mapType typeMap, option ModuleOption, queries map[string]fieldQuery, encrypted map[string]bool, ask bool) error {
 	module := generators.ModuleTemplate{}
 	field := generators.FieldTemplate{}
-	mapType := utils.NewType()
 
 	util.Println("Welcome to Bima Framework Generator")
 	module.Name = name
 
+	if ask {
+		err := interact.NewInteraction("Enable soft delete with restore and trashed endpoints?").Resolve(option.SoftDelete)
+		if err != nil {
+			color.New(color.FgRed).Println(err.Error())
+
+			return err
+		}
+	}
+
 	index := 2
 	more := true
 	for more {
@@ -227,7 +409,12 @@ func create(factory *generators.Factory, util *color.Color, name string) error {
 		}
 
 		if more {
-			column(util, &field, mapType)
+			var encrypt *bool
+			if factory.Driver != "mongo" {
+				encrypt = new(bool)
+			}
+
+			column(util, &field, mapType, encrypt)
 
 			field.Name = strings.Replace(field.Name, " ", "", -1)
 			column := generators.FieldTemplate{}
@@ -237,6 +424,19 @@ func create(factory *generators.Factory, util *color.Color, name string) error {
 			column.Index = index
 			column.Name = cases.Title(language.English, cases.NoLower).String(column.Name)
 			column.NameUnderScore = strcase.ToDelimited(column.Name, '_')
+
+			if encrypt != nil {
+				if *encrypt {
+					encrypted[column.NameUnderScore] = true
+				} else {
+					query := fieldQuery{}
+					queryable(util, &query, column.ProtobufType)
+					if len(query.Operators) > 0 || query.Sortable || query.Searchable {
+						queries[column.NameUnderScore] = query
+					}
+				}
+			}
+
 			module.Fields = append(module.Fields, column)
 
 			field.Name = ""
@@ -246,52 +446,75 @@ func create(factory *generators.Factory, util *color.Color, name string) error {
 		}
 	}
 
+	return generate(factory, util, module, option, queries, encrypted)
+}
+
+func generate(factory *generators.Factory, util *color.Color, module generators.ModuleTemplate, option ModuleOption, queries map[string]fieldQuery, encrypted map[string]bool) error {
 	if len(module.Fields) < 1 {
 		return errors.New("you must have at least one column in table")
 	}
 
+	workDir, _ := os.Getwd()
+	definitions := parseModule(workDir).Definitions
+
 	factory.Generate(module)
 
-	workDir, _ := os.Getwd()
+	registry := parseModule(workDir)
+	registry.Definitions = definitions
+	registry.register(factory.Template, option, queries, encrypted)
+	if err := registry.save(workDir); err != nil {
+		return err
+	}
+
 	fmt.Print("Module ")
-	util.Print(name)
+	util.Print(module.Name)
 	fmt.Printf(" registered in %s/modules.yaml\n", workDir)
 
 	return nil
 }
 
-func column(util *color.Color, field *generators.FieldTemplate, mapType utils.Type) {
+func column(util *color.Color, field *generators.FieldTemplate, mapType typeMap, encrypt *bool) {
 	err := interact.NewInteraction("Input column name?").Resolve(&field.Name)
 	if err != nil {
 		util.Println(err.Error())
-		column(util, field, mapType)
+		column(util, field, mapType, encrypt)
 	}
 
 	if field.Name == "" {
 		util.Println("Column name is required")
-		column(util, field, mapType)
+		column(util, field, mapType, encrypt)
 	}
 
 	field.ProtobufType = "string"
-	err = interact.NewInteraction("Input data type?",
-		interact.Choice{Display: "string", Value: "string"},
-		interact.Choice{Display: "bool", Value: "bool"},
-		interact.Choice{Display: "int32", Value: "int32"},
-		interact.Choice{Display: "int64", Value: "int64"},
-		interact.Choice{Display: "bytes", Value: "bytes"},
-		interact.Choice{Display: "double", Value: "double"},
-		interact.Choice{Display: "float", Value: "float"},
-		interact.Choice{Display: "uint32", Value: "uint32"},
-		interact.Choice{Display: "sint32", Value: "sint32"},
-		interact.Choice{Display: "sint64", Value: "sint64"},
-		interact.Choice{Display: "fixed32", Value: "fixed32"},
-		interact.Choice{Display: "fixed64", Value: "fixed64"},
-		interact.Choice{Display: "sfixed32", Value: "sfixed32"},
-		interact.Choice{Display: "sfixed64", Value: "sfixed64"},
-	).Resolve(&field.ProtobufType)
-	if err != nil {
-		util.Println(err.Error())
-		column(util, field, mapType)
+	if encrypt != nil {
+		err = interact.NewInteraction("Encrypt column?").Resolve(encrypt)
+		if err != nil {
+			util.Println(err.Error())
+			column(util, field, mapType, encrypt)
+		}
+	}
+
+	if encrypt == nil || !*encrypt {
+		err = interact.NewInteraction("Input data type?",
+			interact.Choice{Display: "string", Value: "string"},
+			interact.Choice{Display: "bool", Value: "bool"},
+			interact.Choice{Display: "int32", Value: "int32"},
+			interact.Choice{Display: "int64", Value: "int64"},
+			interact.Choice{Display: "bytes", Value: "bytes"},
+			interact.Choice{Display: "double", Value: "double"},
+			interact.Choice{Display: "float", Value: "float"},
+			interact.Choice{Display: "uint32", Value: "uint32"},
+			interact.Choice{Display: "sint32", Value: "sint32"},
+			interact.Choice{Display: "sint64", Value: "sint64"},
+			interact.Choice{Display: "fixed32", Value: "fixed32"},
+			interact.Choice{Display: "fixed64", Value: "fixed64"},
+			interact.Choice{Display: "sfixed32", Value: "sfixed32"},
+			interact.Choice{Display: "sfixed64", Value: "sfixed64"},
+		).Resolve(&field.ProtobufType)
+		if err != nil {
+			util.Println(err.Error())
+			column(util, field, mapType, encrypt)
+		}
 	}
 
 	field.GolangType = mapType.Value(field.ProtobufType)
@@ -299,6 +522,61 @@ func column(util *color.Color, field *generators.FieldTemplate, mapType utils.Ty
 	err = interact.NewInteraction("Is column required?").Resolve(&field.IsRequired)
 	if err != nil {
 		util.Println(err.Error())
-		column(util, field, mapType)
+		column(util, field, mapType, encrypt)
+	}
+}
+
+func queryable(util *color.Color, query *fieldQuery, protobufType string) {
+	filterable := false
+	err := interact.NewInteraction("Is column filterable?").Resolve(&filterable)
+	if err != nil {
+		util.Println(err.Error())
+		queryable(util, query, protobufType)
+
+		return
+	}
+
+	if filterable {
+		operators := "eq"
+		err = interact.NewInteraction("Input filter operators (eq, ne, gt, gte, lt, lte, like, in)?").Resolve(&operators)
+		if err != nil {
+			util.Println(err.Error())
+			queryable(util, query, protobufType)
+
+			return
+		}
+
+		query.Operators = []string{}
+		for _, v := range strings.Split(operators, ",") {
+			v = strings.TrimSpace(v)
+			switch v {
+			case "eq", "ne", "gt", "gte", "lt", "lte", "like", "in":
+				query.Operators = append(query.Operators, v)
+			case "":
+			default:
+				util.Printf("Operator %s is not supported\n", v)
+				queryable(util, query, protobufType)
+
+				return
+			}
+		}
+	}
+
+	err = interact.NewInteraction("Is column sortable?").Resolve(&query.Sortable)
+	if err != nil {
+		util.Println(err.Error())
+		queryable(util, query, protobufType)
+
+		return
+	}
+
+	if protobufType != "string" {
+		return
+	}
+
+	err = interact.NewInteraction("Is column searchable?").Resolve(&query.Searchable)
+	if err != nil {
+		util.Println(err.Error())
+		queryable(util, query, protobufType)
 	}
 }
@@ -0,0 +1,55 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestModuleOptionConnection(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml": "database:\n    driver: mysql\n    connections:\n        - reporting\n        - ' audit '\n",
+		"config.json": `{"database": {"driver": "mysql", "connections": ["reporting"]}}`,
+		"empty.yaml":  "database:\n    driver: mysql\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(fmt.Sprintf("%s/%s", dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("DB_CONNECTIONS", "reporting, audit")
+
+	cases := []struct {
+		name       string
+		file       string
+		driver     string
+		connection string
+		err        bool
+	}{
+		{name: "yaml", file: "config.yaml", driver: "mysql", connection: "reporting"},
+		{name: "yaml trimmed", file: "config.yaml", driver: "mysql", connection: "audit"},
+		{name: "json", file: "config.json", driver: "mysql", connection: "reporting"},
+		{name: "env", file: ".env", driver: "mysql", connection: "audit"},
+		{name: "yaml ignore environment", file: "empty.yaml", driver: "mysql", connection: "reporting", err: true},
+		{name: "undeclared", file: "config.json", driver: "mysql", connection: "audit", err: true},
+		{name: "mongo", file: "config.yaml", driver: "mongo", connection: "reporting", err: true},
+		{name: "no connection", file: "empty.yaml", driver: "mysql"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			file := c.file
+			if file != ".env" {
+				file = fmt.Sprintf("%s/%s", dir, file)
+			}
+
+			err := ModuleOption{Connection: c.connection}.validate(file, c.driver)
+			if c.err != (err != nil) {
+				t.Errorf("expected error %t, got %v", c.err, err)
+			}
+		})
+	}
+}
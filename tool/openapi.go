@@ -0,0 +1,383 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	openapi struct {
+		Openapi    string                             `json:"openapi"`
+		Info       swaggerInfo                        `json:"info"`
+		Servers    []openapiServer                    `json:"servers"`
+		Tags       []swaggerTag                       `json:"tags,omitempty"`
+		TagGroups  []openapiTagGroup                  `json:"x-tagGroups,omitempty"`
+		Security   []map[string][]string              `json:"security,omitempty"`
+		Paths      map[string]map[string]*openapiPath `json:"paths"`
+		Components openapiComponents                  `json:"components"`
+	}
+
+	openapiServer struct {
+		Url string `json:"url"`
+	}
+
+	openapiTagGroup struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	openapiComponents struct {
+		Schemas         map[string]*schema        `json:"schemas,omitempty"`
+		SecuritySchemes map[string]securityScheme `json:"securitySchemes,omitempty"`
+	}
+
+	openapiPath struct {
+		OperationId string                     `json:"operationId,omitempty"`
+		Summary     string                     `json:"summary,omitempty"`
+		Description string                     `json:"description,omitempty"`
+		Tags        []string                   `json:"tags,omitempty"`
+		Parameters  []openapiParameter         `json:"parameters,omitempty"`
+		RequestBody *openapiBody               `json:"requestBody,omitempty"`
+		Responses   map[string]openapiResponse `json:"responses"`
+	}
+
+	openapiParameter struct {
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description,omitempty"`
+		Required    bool    `json:"required,omitempty"`
+		Schema      *schema `json:"schema"`
+	}
+
+	openapiBody struct {
+		Required bool                        `json:"required,omitempty"`
+		Content  map[string]openapiMediaType `json:"content"`
+	}
+
+	openapiResponse struct {
+		Description string                      `json:"description"`
+		Content     map[string]openapiMediaType `json:"content,omitempty"`
+	}
+
+	openapiMediaType struct {
+		Schema *schema `json:"schema"`
+	}
+
+	customRoute struct {
+		Name   string
+		Path   string
+		Method string
+	}
+)
+
+const routeTag = "Routes"
+
+func (d Docs) Build(format string, output string) error {
+	if format != "yaml" && format != "json" {
+		return fmt.Errorf("format %s is not supported, use yaml or json", format)
+	}
+
+	workDir, _ := os.Getwd()
+	document, err := d.document(workDir)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(document, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if format == "yaml" {
+		ordered := yaml.MapSlice{}
+		if err = yaml.Unmarshal(content, &ordered); err != nil {
+			return err
+		}
+
+		if content, err = yaml.Marshal(ordered); err != nil {
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, content, 0644)
+}
+
+func (d Docs) document(workDir string) (openapi, error) {
+	modules, err := swaggers(workDir)
+	if err != nil {
+		return openapi{}, err
+	}
+
+	routes, err := parseRoutes(fmt.Sprintf("%s/routes", workDir))
+	if err != nil {
+		return openapi{}, err
+	}
+
+	env := d.env()
+	document := openapi{
+		Openapi: "3.1.0",
+		Info:    swaggerInfo{Title: env.Service, Version: "1.0.0"},
+		Servers: []openapiServer{{Url: fmt.Sprintf("http://localhost:%d", env.HttpPort)}},
+		Paths:   map[string]map[string]*openapiPath{},
+		Components: openapiComponents{
+			Schemas:         map[string]*schema{},
+			SecuritySchemes: map[string]securityScheme{},
+		},
+	}
+
+	if document.Info.Title == "" {
+		document.Info.Title = filepath.Base(workDir)
+	}
+
+	tags := map[string]string{}
+	for _, m := range modules {
+		for k, v := range m.Spec.Definitions {
+			converted := component(v)
+			if registered, ok := document.Components.Schemas[k]; ok && !reflect.DeepEqual(registered, converted) {
+				return openapi{}, fmt.Errorf("definition %s in %s conflicts with other module", k, m.File)
+			}
+
+			document.Components.Schemas[k] = converted
+		}
+
+		for k, v := range m.Spec.SecurityDefinitions {
+			document.Components.SecuritySchemes[k] = v
+		}
+
+		if document.Security == nil {
+			document.Security = m.Spec.Security
+		}
+
+		for _, t := range m.Spec.Tags {
+			tags[t.Name] = t.Description
+		}
+
+		for _, e := range m.Spec.endpoints() {
+			for _, t := range e.Operation.Tags {
+				if _, ok := tags[t]; !ok {
+					tags[t] = ""
+				}
+			}
+
+			if _, ok := document.Paths[e.Path]; !ok {
+				document.Paths[e.Path] = map[string]*openapiPath{}
+			}
+
+			document.Paths[e.Path][e.Method] = convert(e.Operation)
+		}
+	}
+
+	names := make([]string, 0, len(tags))
+	for k := range tags {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		document.Tags = append(document.Tags, swaggerTag{Name: name, Description: tags[name]})
+	}
+
+	if len(names) > 0 {
+		document.TagGroups = append(document.TagGroups, openapiTagGroup{Name: "Modules", Tags: names})
+	}
+
+	if len(routes) > 0 {
+		document.Tags = append(document.Tags, swaggerTag{Name: routeTag, Description: "Custom routes"})
+		document.TagGroups = append(document.TagGroups, openapiTagGroup{Name: routeTag, Tags: []string{routeTag}})
+	}
+
+	for _, r := range routes {
+		path := r.Path
+		if path != "/health" {
+			path = env.ApiPrefix + path
+		}
+
+		operation := &openapiPath{
+			OperationId: fmt.Sprintf("%s_%s", routeTag, r.Name),
+			Summary:     r.Name,
+			Tags:        []string{routeTag},
+			Responses:   map[string]openapiResponse{"200": {Description: "A successful response."}},
+		}
+
+		for _, match := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+			operation.Parameters = append(operation.Parameters, openapiParameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &schema{Type: "string"},
+			})
+		}
+
+		if _, ok := document.Paths[path]; !ok {
+			document.Paths[path] = map[string]*openapiPath{}
+		}
+
+		document.Paths[path][strings.ToLower(r.Method)] = operation
+	}
+
+	return document, nil
+}
+
+func convert(o *operation) *openapiPath {
+	path := &openapiPath{
+		OperationId: o.OperationId,
+		Summary:     o.Summary,
+		Description: o.Description,
+		Tags:        o.Tags,
+		Responses:   map[string]openapiResponse{},
+	}
+
+	for _, p := range o.Parameters {
+		if p.In == "body" {
+			path.RequestBody = &openapiBody{
+				Required: p.Required,
+				Content:  map[string]openapiMediaType{"application/json": {Schema: component(p.Schema)}},
+			}
+
+			continue
+		}
+
+		path.Parameters = append(path.Parameters, openapiParameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      component(p.schema()),
+		})
+	}
+
+	for code, r := range o.Responses {
+		response := openapiResponse{Description: r.Description}
+		if r.Schema != nil {
+			response.Content = map[string]openapiMediaType{"application/json": {Schema: component(r.Schema)}}
+		}
+
+		path.Responses[code] = response
+	}
+
+	return path
+}
+
+func component(s *schema) *schema {
+	if s == nil {
+		return nil
+	}
+
+	converted := *s
+	if s.Ref != "" {
+		converted.Ref = fmt.Sprintf("#/components/schemas/%s", refName(s.Ref))
+	}
+
+	converted.Items = component(s.Items)
+	converted.AdditionalProperties = component(s.AdditionalProperties)
+	if s.Properties != nil {
+		converted.Properties = map[string]*schema{}
+		for k, v := range s.Properties {
+			converted.Properties[k] = component(v)
+		}
+	}
+
+	return &converted
+}
+
+func parseRoutes(dir string) ([]customRoute, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".go" {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routes := []customRoute{}
+	for _, file := range files {
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		paths := map[string]string{}
+		verbs := map[string]string{}
+		for _, declaration := range parsed.Decls {
+			function, ok := declaration.(*ast.FuncDecl)
+			if !ok || function.Recv == nil || len(function.Recv.List) == 0 || function.Body == nil {
+				continue
+			}
+
+			receiver := function.Recv.List[0].Type
+			if star, ok := receiver.(*ast.StarExpr); ok {
+				receiver = star.X
+			}
+
+			name, ok := receiver.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			switch function.Name.Name {
+			case "Path":
+				paths[name.Name] = returned(function.Body)
+			case "Method":
+				verbs[name.Name] = returned(function.Body)
+			}
+		}
+
+		for name, path := range paths {
+			method, ok := verbs[name]
+			if !ok || path == "" || method == "" {
+				continue
+			}
+
+			routes = append(routes, customRoute{Name: name, Path: path, Method: method})
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Name < routes[j].Name
+	})
+
+	return routes, nil
+}
+
+func returned(body *ast.BlockStmt) string {
+	for _, statement := range body.List {
+		r, ok := statement.(*ast.ReturnStmt)
+		if !ok || len(r.Results) != 1 {
+			continue
+		}
+
+		switch value := r.Results[0].(type) {
+		case *ast.BasicLit:
+			if value.Kind != token.STRING {
+				return ""
+			}
+
+			text, _ := strconv.Unquote(value.Value)
+
+			return text
+		case *ast.SelectorExpr:
+			return strings.ToUpper(strings.TrimPrefix(value.Sel.Name, "Method"))
+		}
+	}
+
+	return ""
+}
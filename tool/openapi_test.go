@@ -0,0 +1,196 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		name      string
+		operation *operation
+		expected  *openapiPath
+	}{
+		{
+			name: "body become request body",
+			operation: &operation{
+				OperationId: "Todos_Create",
+				Summary:     "Create todo",
+				Tags:        []string{"Todos"},
+				Parameters: []parameter{
+					{Name: "body", In: "body", Required: true, Schema: &schema{Ref: "#/definitions/v1Todo"}},
+				},
+				Responses: map[string]response{
+					"200": {Description: "A successful response.", Schema: &schema{Ref: "#/definitions/v1TodoResponse"}},
+				},
+			},
+			expected: &openapiPath{
+				OperationId: "Todos_Create",
+				Summary:     "Create todo",
+				Tags:        []string{"Todos"},
+				RequestBody: &openapiBody{
+					Required: true,
+					Content:  map[string]openapiMediaType{"application/json": {Schema: &schema{Ref: "#/components/schemas/v1Todo"}}},
+				},
+				Responses: map[string]openapiResponse{
+					"200": {Description: "A successful response.", Content: map[string]openapiMediaType{"application/json": {Schema: &schema{Ref: "#/components/schemas/v1TodoResponse"}}}},
+				},
+			},
+		},
+		{
+			name: "parameters become schema",
+			operation: &operation{
+				OperationId: "Todos_GetPaginated",
+				Parameters: []parameter{
+					{Name: "id", In: "path", Required: true, Type: "string"},
+					{Name: "page", In: "query", Type: "integer", Format: "int32"},
+					{Name: "fields", In: "query", Type: "array", Items: &schema{Type: "string"}, CollectionFormat: "multi"},
+				},
+				Responses: map[string]response{
+					"default": {Description: "An unexpected error response."},
+				},
+			},
+			expected: &openapiPath{
+				OperationId: "Todos_GetPaginated",
+				Parameters: []openapiParameter{
+					{Name: "id", In: "path", Required: true, Schema: &schema{Type: "string"}},
+					{Name: "page", In: "query", Schema: &schema{Type: "integer", Format: "int32"}},
+					{Name: "fields", In: "query", Schema: &schema{Type: "array", Items: &schema{Type: "string"}}},
+				},
+				Responses: map[string]openapiResponse{
+					"default": {Description: "An unexpected error response."},
+				},
+			},
+		},
+		{
+			name:      "no response",
+			operation: &operation{OperationId: "Todos_Delete"},
+			expected:  &openapiPath{OperationId: "Todos_Delete", Responses: map[string]openapiResponse{}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if result := convert(c.operation); !reflect.DeepEqual(result, c.expected) {
+				t.Errorf("expected %+v, got %+v", c.expected, result)
+			}
+		})
+	}
+}
+
+func TestComponent(t *testing.T) {
+	cases := []struct {
+		name     string
+		schema   *schema
+		expected *schema
+	}{
+		{
+			name:     "nil",
+			schema:   nil,
+			expected: nil,
+		},
+		{
+			name:     "ref",
+			schema:   &schema{Ref: "#/definitions/v1Todo"},
+			expected: &schema{Ref: "#/components/schemas/v1Todo"},
+		},
+		{
+			name:     "array items",
+			schema:   &schema{Type: "array", Items: &schema{Ref: "#/definitions/v1Todo"}},
+			expected: &schema{Type: "array", Items: &schema{Ref: "#/components/schemas/v1Todo"}},
+		},
+		{
+			name: "nested properties",
+			schema: &schema{Type: "object", Properties: map[string]*schema{
+				"data":  {Ref: "#/definitions/v1Todo"},
+				"meta":  {Type: "object", AdditionalProperties: &schema{Ref: "#/definitions/protobufAny"}},
+				"total": {Type: "integer"},
+			}},
+			expected: &schema{Type: "object", Properties: map[string]*schema{
+				"data":  {Ref: "#/components/schemas/v1Todo"},
+				"meta":  {Type: "object", AdditionalProperties: &schema{Ref: "#/components/schemas/protobufAny"}},
+				"total": {Type: "integer"},
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if result := component(c.schema); !reflect.DeepEqual(result, c.expected) {
+				t.Errorf("expected %+v, got %+v", c.expected, result)
+			}
+		})
+	}
+
+	original := &schema{Ref: "#/definitions/v1Todo"}
+	component(original)
+	if original.Ref != "#/definitions/v1Todo" {
+		t.Errorf("source schema is modified: %s", original.Ref)
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	files := map[string]string{
+		"health.go": `package routes
+
+import "net/http"
+
+type Health struct{}
+
+func (r *Health) Path() string {
+	return "/health"
+}
+
+func (r *Health) Method() string {
+	return http.MethodGet
+}
+`,
+		"admin/audit.go": `package admin
+
+type Audit struct{}
+
+func (r Audit) Path() string {
+	return "/admin/audit"
+}
+
+func (r Audit) Method() string {
+	return "POST"
+}
+`,
+		"dic.go": `package routes
+
+type Helper struct{}
+
+func (h Helper) Path() string {
+	return "/helper"
+}
+`,
+	}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(fmt.Sprintf("%s/admin", dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(fmt.Sprintf("%s/%s", dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	routes, err := parseRoutes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []customRoute{
+		{Name: "Audit", Path: "/admin/audit", Method: "POST"},
+		{Name: "Health", Path: "/health", Method: "GET"},
+	}
+
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, routes)
+	}
+}
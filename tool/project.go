@@ -0,0 +1,126 @@
+package tool
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/bimalabs/framework/v4/utils"
+	"github.com/gertd/go-pluralize"
+	"gopkg.in/yaml.v2"
+)
+
+const p = "configs/bima.yaml"
+
+type (
+	project struct {
+		Types     map[string]mapping `yaml:"types"`
+		Plurals   plural             `yaml:"plurals"`
+		Templates string             `yaml:"templates"`
+	}
+
+	plural struct {
+		Irregular   map[string]string `yaml:"irregular"`
+		Uncountable []string          `yaml:"uncountable"`
+	}
+
+	mapping struct {
+		Protobuf string `yaml:"protobuf"`
+		Golang   string `yaml:"golang"`
+		Column   string `yaml:"column"`
+		Length   int    `yaml:"length"`
+	}
+
+	typeMap struct {
+		utils.Type
+		overrides map[string]mapping
+	}
+)
+
+func parseProject(dir string) project {
+	var path strings.Builder
+	path.WriteString(dir)
+	path.WriteString("/")
+	path.WriteString(p)
+
+	config := project{}
+	content, err := os.ReadFile(path.String())
+	if err != nil {
+		return config
+	}
+
+	err = yaml.Unmarshal(content, &config)
+	if err != nil {
+		log.Println(err)
+
+		return project{}
+	}
+
+	return config
+}
+
+func (p project) types() typeMap {
+	return typeMap{
+		Type:      utils.NewType(),
+		overrides: p.Types,
+	}
+}
+
+func (p project) pluralizer() *pluralize.Client {
+	client := pluralize.NewClient()
+	for single, plural := range p.Plurals.Irregular {
+		client.AddIrregularRule(single, plural)
+	}
+
+	for _, word := range p.Plurals.Uncountable {
+		client.AddUncountableRule(word)
+	}
+
+	return client
+}
+
+func (p project) templates() string {
+	if p.Templates == "" {
+		return "templates"
+	}
+
+	return strings.Trim(p.Templates, "/")
+}
+
+func (t typeMap) Value(key string) string {
+	if m, ok := t.overrides[key]; ok && m.Golang != "" {
+		return m.Golang
+	}
+
+	return t.Type.Value(key)
+}
+
+func (t typeMap) Protobuf(key string) string {
+	if m, ok := t.overrides[key]; ok && m.Protobuf != "" {
+		return m.Protobuf
+	}
+
+	return key
+}
+
+func (t typeMap) Column(key string) string {
+	m, ok := t.overrides[key]
+	if !ok || m.Column == "" {
+		return ""
+	}
+
+	if m.Length > 0 && !strings.Contains(m.Column, "(") {
+		return fmt.Sprintf("%s(%d)", m.Column, m.Length)
+	}
+
+	return m.Column
+}
+
+func (t typeMap) Length(key string) int {
+	if m, ok := t.overrides[key]; ok {
+		return m.Length
+	}
+
+	return 0
+}
@@ -0,0 +1,366 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/joho/godotenv"
+)
+
+type (
+	Sdk string
+
+	sdkType struct {
+		Name   string
+		Type   string
+		Fields []string
+	}
+
+	sdkQuery struct {
+		Name   string
+		Fields []string
+	}
+
+	sdkFunction struct {
+		Name     string
+		Alias    string
+		Summary  string
+		Method   string
+		Path     string
+		Params   string
+		Options  string
+		Response string
+	}
+
+	sdkModule struct {
+		Namespace string
+		File      string
+		Imports   string
+		Queries   []sdkQuery
+		Functions []sdkFunction
+	}
+
+	typescript struct {
+		names map[string]string
+		refs  map[string]bool
+	}
+)
+
+var (
+	identifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	prefixRegex     = regexp.MustCompile(`^[a-z0-9]+([A-Z].*)$`)
+	pathParamRegex  = regexp.MustCompile(`{([^}=]+)(=[^}]*)?}`)
+
+	reserved = map[string]bool{
+		"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+		"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+		"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+		"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+		"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+		"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+	}
+)
+
+func (s Sdk) Generate(output string) error {
+	if s != "ts" {
+		return fmt.Errorf("sdk language %s is not supported, use ts", string(s))
+	}
+
+	workDir, _ := os.Getwd()
+	modules, err := swaggers(workDir)
+	if err != nil {
+		return err
+	}
+
+	definitions := map[string]*schema{}
+	for _, m := range modules {
+		for k, v := range m.Spec.Definitions {
+			definitions[k] = v
+		}
+	}
+
+	if err = os.MkdirAll(output, 0755); err != nil {
+		return err
+	}
+
+	ts := typescript{names: typeNames(definitions)}
+
+	port := "7777"
+	if env, err := godotenv.Read(fmt.Sprintf("%s/.env", workDir)); err == nil && env["APP_PORT"] != "" {
+		port = env["APP_PORT"]
+	}
+
+	render(fmt.Sprintf("%s/client.ts", output), sdkClient, map[string]string{"BaseUrl": fmt.Sprintf("http://localhost:%s", port)})
+
+	keys := make([]string, 0, len(definitions))
+	for k := range definitions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	types := []sdkType{}
+	for _, k := range keys {
+		types = append(types, ts.declare(ts.names[k], definitions[k]))
+	}
+
+	render(fmt.Sprintf("%s/types.ts", output), sdkTypes, types)
+
+	files := []sdkModule{}
+	for _, m := range modules {
+		module := ts.module(m)
+		render(fmt.Sprintf("%s/%s.ts", output, module.File), sdkFile, module)
+
+		files = append(files, module)
+	}
+
+	render(fmt.Sprintf("%s/index.ts", output), sdkIndex, files)
+
+	return nil
+}
+
+func (t typescript) module(m swaggerModule) sdkModule {
+	module := sdkModule{
+		Namespace: strcase.ToLowerCamel(m.File),
+		File:      m.File,
+	}
+
+	t.refs = map[string]bool{}
+	declared := map[string]bool{}
+	for _, e := range m.Spec.endpoints() {
+		name := strcase.ToLowerCamel(e.Name)
+		if name == "" {
+			name = strcase.ToLowerCamel(fmt.Sprintf("%s %s", e.Method, e.Path))
+		}
+
+		if declared[name] {
+			continue
+		}
+
+		declared[name] = true
+		module.Functions = append(module.Functions, t.function(name, strings.ToUpper(e.Method), e.Path, e.Operation, &module))
+	}
+
+	imports := make([]string, 0, len(t.refs))
+	for k := range t.refs {
+		imports = append(imports, k)
+	}
+	sort.Strings(imports)
+
+	module.Imports = strings.Join(imports, ", ")
+
+	return module
+}
+
+func (t typescript) function(name string, method string, path string, operation *operation, module *sdkModule) sdkFunction {
+	function := sdkFunction{
+		Name:     name,
+		Summary:  operation.Summary,
+		Method:   method,
+		Response: "unknown",
+	}
+
+	if reserved[name] {
+		function.Alias = fmt.Sprintf("_%s", name)
+	}
+
+	if r, ok := operation.Responses["200"]; ok && r.Schema != nil {
+		function.Response = t.of(r.Schema)
+	}
+
+	params := []string{}
+	options := []string{}
+	query := sdkQuery{Name: fmt.Sprintf("%sQuery", strcase.ToCamel(name))}
+	required := false
+	for _, p := range operation.Parameters {
+		switch p.In {
+		case "path":
+			params = append(params, fmt.Sprintf("%s: %s", strcase.ToLowerCamel(p.Name), t.of(p.schema())))
+		case "body":
+			params = append(params, fmt.Sprintf("body: %s", t.of(p.schema())))
+			options = append(options, "body")
+		case "query":
+			required = required || p.Required
+			query.Fields = append(query.Fields, t.property(p.Name, p.schema(), p.Required))
+		}
+	}
+
+	if len(query.Fields) > 0 {
+		module.Queries = append(module.Queries, query)
+		if required {
+			params = append(params, fmt.Sprintf("query: %s", query.Name))
+		} else {
+			params = append(params, fmt.Sprintf("query: %s = {}", query.Name))
+		}
+
+		options = append(options, "query")
+	}
+
+	params = append(params, "init?: RequestInit")
+	options = append(options, "init")
+
+	function.Params = strings.Join(params, ", ")
+	function.Options = strings.Join(options, ", ")
+
+	segments := []string{}
+	last := 0
+	for _, match := range pathParamRegex.FindAllStringSubmatchIndex(path, -1) {
+		if match[0] > last {
+			segments = append(segments, fmt.Sprintf("'%s'", path[last:match[0]]))
+		}
+
+		segments = append(segments, fmt.Sprintf("encodeURIComponent(String(%s))", strcase.ToLowerCamel(path[match[2]:match[3]])))
+		last = match[1]
+	}
+
+	if last < len(path) {
+		segments = append(segments, fmt.Sprintf("'%s'", path[last:]))
+	}
+
+	function.Path = strings.Join(segments, " + ")
+
+	return function
+}
+
+func (t typescript) declare(name string, s *schema) sdkType {
+	declaration := sdkType{Name: name}
+	if s == nil || s.Type == "array" || len(s.Enum) > 0 || len(s.Properties) == 0 {
+		declaration.Type = t.of(s)
+
+		return declaration
+	}
+
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		declaration.Fields = append(declaration.Fields, t.property(k, s.Properties[k], s.required(k)))
+	}
+
+	if s.AdditionalProperties != nil {
+		declaration.Fields = append(declaration.Fields, "[key: string]: unknown")
+	}
+
+	return declaration
+}
+
+func (t typescript) property(name string, s *schema, required bool) string {
+	if !identifierRegex.MatchString(name) {
+		name = strconv.Quote(name)
+	}
+
+	if required {
+		return fmt.Sprintf("%s: %s", name, t.of(s))
+	}
+
+	return fmt.Sprintf("%s?: %s", name, t.of(s))
+}
+
+func (t typescript) of(s *schema) string {
+	if s == nil {
+		return "unknown"
+	}
+
+	if s.Ref != "" {
+		name := t.names[refName(s.Ref)]
+		if name == "" {
+			return "unknown"
+		}
+
+		if t.refs != nil {
+			t.refs[name] = true
+		}
+
+		return name
+	}
+
+	switch s.Type {
+	case "string":
+		if len(s.Enum) == 0 {
+			return "string"
+		}
+
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			values = append(values, strconv.Quote(v))
+		}
+
+		return strings.Join(values, " | ")
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		item := t.of(s.Items)
+		if strings.Contains(item, " ") {
+			return fmt.Sprintf("Array<%s>", item)
+		}
+
+		return fmt.Sprintf("%s[]", item)
+	}
+
+	if len(s.Properties) > 0 {
+		keys := make([]string, 0, len(s.Properties))
+		for k := range s.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fields := make([]string, 0, len(keys))
+		for _, k := range keys {
+			fields = append(fields, t.property(k, s.Properties[k], s.required(k)))
+		}
+
+		return fmt.Sprintf("{ %s }", strings.Join(fields, "; "))
+	}
+
+	if s.AdditionalProperties != nil {
+		return fmt.Sprintf("Record<string, %s>", t.of(s.AdditionalProperties))
+	}
+
+	return "Record<string, unknown>"
+}
+
+func (s *schema) required(name string) bool {
+	for _, v := range s.Required {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeNames(definitions map[string]*schema) map[string]string {
+	used := map[string][]string{}
+	for k := range definitions {
+		name := strcase.ToCamel(k)
+		if match := prefixRegex.FindStringSubmatch(k); match != nil {
+			name = match[1]
+		}
+
+		used[name] = append(used[name], k)
+	}
+
+	names := map[string]string{}
+	for name, keys := range used {
+		for _, k := range keys {
+			if len(keys) > 1 {
+				names[k] = strcase.ToCamel(k)
+
+				continue
+			}
+
+			names[k] = name
+		}
+	}
+
+	return names
+}
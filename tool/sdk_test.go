@@ -0,0 +1,53 @@
+package tool
+
+import "testing"
+
+func TestTypescriptProperty(t *testing.T) {
+	cases := []struct {
+		name     string
+		property string
+		schema   *schema
+		required bool
+		expected string
+	}{
+		{
+			name:     "optional",
+			property: "task",
+			schema:   &schema{Type: "string"},
+			expected: "task?: string",
+		},
+		{
+			name:     "required",
+			property: "done",
+			schema:   &schema{Type: "boolean"},
+			required: true,
+			expected: "done: boolean",
+		},
+		{
+			name:     "enum",
+			property: "status",
+			schema:   &schema{Type: "string", Enum: []string{"OPEN", "CLOSED"}},
+			expected: `status?: "OPEN" | "CLOSED"`,
+		},
+		{
+			name:     "escaped enum",
+			property: "note",
+			schema:   &schema{Type: "string", Enum: []string{"it's", `say "hi"`, `back\slash`, "new\nline"}},
+			expected: `note?: "it's" | "say \"hi\"" | "back\\slash" | "new\nline"`,
+		},
+		{
+			name:     "escaped name",
+			property: `x-'quoted"`,
+			schema:   &schema{Type: "number"},
+			expected: `"x-'quoted\""?: number`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if result := (typescript{}).property(c.property, c.schema, c.required); result != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package tool
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type watcher struct {
+	dir     string
+	state   string
+	mutex   sync.Mutex
+	clients map[chan bool]bool
+}
+
+// assets/docs vendor swagger-ui-dist 5.18.2, listed one by one so build fail when a file is missing
+//
+//go:embed assets/docs/index.html assets/docs/swagger-initializer.js assets/docs/swagger-ui.css assets/docs/swagger-ui-bundle.js assets/docs/swagger-ui-standalone-preset.js
+var assets embed.FS
+
+func (d Docs) Serve(port int) error {
+	ui, err := fs.Sub(assets, "assets/docs")
+	if err != nil {
+		return err
+	}
+
+	workDir, _ := os.Getwd()
+	dir := fmt.Sprintf("%s/swaggers", workDir)
+	if _, err = os.Stat(dir); err != nil {
+		return err
+	}
+
+	live := &watcher{dir: dir, clients: map[chan bool]bool{}}
+	go live.watch(time.Second)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(ui)))
+	mux.Handle("/events", live)
+	mux.Handle("/openapi.json", nocache(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		document, err := d.document(workDir)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(document)
+	})))
+
+	fmt.Printf("Serving api documentation on http://localhost:%d\n", port)
+
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+}
+
+func nocache(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (w *watcher) snapshot() string {
+	files, _ := filepath.Glob(fmt.Sprintf("%s/*.json", w.dir))
+
+	var state strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+
+		state.WriteString(fmt.Sprintf("%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano()))
+	}
+
+	return state.String()
+}
+
+func (w *watcher) watch(interval time.Duration) {
+	w.state = w.snapshot()
+	for range time.Tick(interval) {
+		state := w.snapshot()
+		if state == w.state {
+			continue
+		}
+
+		w.state = state
+		w.mutex.Lock()
+		for client := range w.clients {
+			select {
+			case client <- true:
+			default:
+			}
+		}
+		w.mutex.Unlock()
+	}
+}
+
+func (w *watcher) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	flusher, ok := response.(http.Flusher)
+	if !ok {
+		http.Error(response, "streaming is not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	flusher.Flush()
+
+	client := make(chan bool, 1)
+	w.mutex.Lock()
+	w.clients[client] = true
+	w.mutex.Unlock()
+
+	defer func() {
+		w.mutex.Lock()
+		delete(w.clients, client)
+		w.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-client:
+			fmt.Fprint(response, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bimalabs/framework/v4/configs"
+	"github.com/bimalabs/generators"
+	"github.com/fatih/color"
+	"github.com/iancoleman/strcase"
+	"github.com/vito/go-interact/interact"
+	"gopkg.in/yaml.v2"
+)
+
+type Specification string
+
+func (s Specification) Create(file string, schemas []string, option ModuleOption) error {
+	content, err := os.ReadFile(string(s))
+	if err != nil {
+		color.New(color.FgRed).Println(err.Error())
+
+		return err
+	}
+
+	document := yaml.MapSlice{}
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		color.New(color.FgRed).Println(err.Error())
+
+		return err
+	}
+
+	definitions := specDefinitions(document)
+	if len(definitions) == 0 {
+		err = fmt.Errorf("no schema found in %s", string(s))
+		color.New(color.FgRed).Println(err.Error())
+
+		return err
+	}
+
+	if len(schemas) == 0 {
+		for _, v := range definitions {
+			if len(specProperties(definitions, yamlMap(v.Value), 0)) == 0 {
+				continue
+			}
+
+			selected := false
+			err = interact.NewInteraction(fmt.Sprintf("Create module from schema %s?", v.Key)).Resolve(&selected)
+			if err != nil {
+				color.New(color.FgRed).Println(err.Error())
+
+				return err
+			}
+
+			if selected {
+				schemas = append(schemas, fmt.Sprint(v.Key))
+			}
+		}
+	}
+
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	if option.Path != "" && len(schemas) > 1 {
+		err = errors.New("path can only be used when generating single schema")
+		color.New(color.FgRed).Println(err.Error())
+
+		return err
+	}
+
+	if err = Call("dump"); err != nil {
+		color.New(color.FgRed).Println("Error updating services container")
+
+		return err
+	}
+
+	env := configs.Env{}
+	config(&env, file, filepath.Ext(file))
+
+	workDir, _ := os.Getwd()
+	setting := parseProject(workDir)
+	termColor := color.New(color.FgGreen, color.Bold)
+	created := []Module{}
+	rollback := func(err error) error {
+		color.New(color.FgRed).Println(err.Error())
+		for _, m := range created {
+			_ = m.Remove()
+		}
+
+		return err
+	}
+
+	for _, name := range schemas {
+		definition := yamlMap(yamlLookup(definitions, name))
+		if definition == nil {
+			return rollback(fmt.Errorf("schema %s is not found in %s", name, string(s)))
+		}
+
+		m := Module(moduleName(name))
+		moduleOption, _, err := m.prepare(file, env, option)
+		if err != nil {
+			return rollback(err)
+		}
+
+		module := generators.ModuleTemplate{Name: string(m)}
+		module.Fields, err = specFields(definitions, definition, setting.types())
+		if err != nil {
+			return rollback(fmt.Errorf("schema %s: %s", name, err.Error()))
+		}
+
+		queries := map[string]fieldQuery{}
+		encrypted := map[string]bool{}
+		generator := NewGenerator(env.Db.Driver, env.ApiPrefix, setting, moduleOption, queries, encrypted)
+		if err = generate(generator, termColor, module, moduleOption, queries, encrypted); err != nil {
+			created = append(created, m)
+
+			return rollback(err)
+		}
+
+		created = append(created, m)
+	}
+
+	return finalize(created...)
+}
+
+func specDefinitions(document yaml.MapSlice) yaml.MapSlice {
+	if schemas := yamlMap(yamlLookup(yamlMap(yamlLookup(document, "components")), "schemas")); len(schemas) > 0 {
+		return schemas
+	}
+
+	return yamlMap(yamlLookup(document, "definitions"))
+}
+
+func specFields(definitions yaml.MapSlice, definition yaml.MapSlice, mapType typeMap) ([]generators.FieldTemplate, error) {
+	required := map[string]bool{}
+	for _, v := range yamlList(yamlLookup(definition, "required")) {
+		required[fmt.Sprint(v)] = true
+	}
+
+	for _, item := range yamlList(yamlLookup(definition, "allOf")) {
+		for _, v := range yamlList(yamlLookup(specResolve(definitions, yamlMap(item), 0), "required")) {
+			required[fmt.Sprint(v)] = true
+		}
+	}
+
+	columns := []generators.FieldTemplate{}
+	declared := map[string]bool{}
+	index := 2
+	for _, property := range specProperties(definitions, definition, 0) {
+		name := fmt.Sprint(property.Key)
+		if strings.EqualFold(name, "id") || declared[name] {
+			continue
+		}
+
+		declared[name] = true
+
+		field := specResolve(definitions, yamlMap(property.Value), 0)
+		if read, _ := yamlLookup(field, "readOnly").(bool); read {
+			continue
+		}
+
+		protobufType := specType(field)
+		if protobufType == "" {
+			color.New(color.FgYellow).Printf("Column %s is skipped, only scalar type is supported\n", name)
+
+			continue
+		}
+
+		column := generators.FieldTemplate{
+			Name:         strcase.ToCamel(name),
+			ProtobufType: protobufType,
+			GolangType:   mapType.Value(protobufType),
+			IsRequired:   required[name],
+			Index:        index,
+		}
+		column.NameUnderScore = strcase.ToDelimited(column.Name, '_')
+
+		columns = append(columns, column)
+		index++
+	}
+
+	if len(columns) == 0 {
+		return nil, errors.New("you must have at least one column in table")
+	}
+
+	return columns, nil
+}
+
+func specProperties(definitions yaml.MapSlice, definition yaml.MapSlice, depth int) yaml.MapSlice {
+	definition = specResolve(definitions, definition, depth)
+	result := yamlMap(yamlLookup(definition, "properties"))
+	for _, item := range yamlList(yamlLookup(definition, "allOf")) {
+		result = append(result, specProperties(definitions, yamlMap(item), depth+1)...)
+	}
+
+	return result
+}
+
+func specResolve(definitions yaml.MapSlice, definition yaml.MapSlice, depth int) yaml.MapSlice {
+	ref, ok := yamlLookup(definition, "$ref").(string)
+	if !ok || depth > 10 {
+		return definition
+	}
+
+	name := ref[strings.LastIndex(ref, "/")+1:]
+
+	return specResolve(definitions, yamlMap(yamlLookup(definitions, name)), depth+1)
+}
+
+func specType(field yaml.MapSlice) string {
+	kind, _ := yamlLookup(field, "type").(string)
+	format, _ := yamlLookup(field, "format").(string)
+	if types := yamlList(yamlLookup(field, "type")); len(types) > 0 {
+		for _, v := range types {
+			if v != "null" {
+				kind = fmt.Sprint(v)
+			}
+		}
+	}
+
+	switch kind {
+	case "string":
+		switch format {
+		case "byte", "binary":
+			return "bytes"
+		case "int64", "uint64":
+			return "int64"
+		}
+
+		return "string"
+	case "integer":
+		switch format {
+		case "int64":
+			return "int64"
+		case "uint32":
+			return "uint32"
+		}
+
+		return "int32"
+	case "number":
+		if format == "float" {
+			return "float"
+		}
+
+		return "double"
+	case "boolean":
+		return "bool"
+	}
+
+	return ""
+}
+
+func moduleName(schema string) string {
+	if index := strings.LastIndex(schema, "."); index >= 0 {
+		schema = schema[index+1:]
+	}
+
+	return strcase.ToCamel(schema)
+}
+
+func yamlLookup(node yaml.MapSlice, key string) interface{} {
+	for _, v := range node {
+		if fmt.Sprint(v.Key) == key {
+			return v.Value
+		}
+	}
+
+	return nil
+}
+
+func yamlMap(value interface{}) yaml.MapSlice {
+	node, _ := value.(yaml.MapSlice)
+
+	return node
+}
+
+func yamlList(value interface{}) []interface{} {
+	items, _ := value.([]interface{})
+
+	return items
+}
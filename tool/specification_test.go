@@ -0,0 +1,210 @@
+package tool
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestSpecDefinitions(t *testing.T) {
+	cases := []struct {
+		name     string
+		document string
+		expected []string
+	}{
+		{
+			name:     "swagger definitions",
+			document: "definitions:\n  Pet:\n    type: object\n",
+			expected: []string{"Pet"},
+		},
+		{
+			name:     "openapi components",
+			document: "components:\n  schemas:\n    Order:\n      type: object\n",
+			expected: []string{"Order"},
+		},
+		{
+			name:     "components without schemas",
+			document: "definitions:\n  Pet:\n    type: object\ncomponents:\n  securitySchemes:\n    bearer:\n      type: http\n",
+			expected: []string{"Pet"},
+		},
+		{
+			name:     "empty components schemas",
+			document: "definitions:\n  Pet:\n    type: object\ncomponents:\n  schemas: {}\n",
+			expected: []string{"Pet"},
+		},
+		{
+			name:     "no schema",
+			document: "info:\n  title: empty\n",
+			expected: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			document := yaml.MapSlice{}
+			if err := yaml.Unmarshal([]byte(c.document), &document); err != nil {
+				t.Fatal(err)
+			}
+
+			names := []string{}
+			for _, v := range specDefinitions(document) {
+				names = append(names, v.Key.(string))
+			}
+
+			if !reflect.DeepEqual(names, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, names)
+			}
+		})
+	}
+}
+
+func TestSpecType(t *testing.T) {
+	cases := []struct {
+		field    string
+		expected string
+	}{
+		{field: "type: string", expected: "string"},
+		{field: "{type: string, format: byte}", expected: "bytes"},
+		{field: "{type: string, format: binary}", expected: "bytes"},
+		{field: "{type: string, format: int64}", expected: "int64"},
+		{field: "type: integer", expected: "int32"},
+		{field: "{type: integer, format: int64}", expected: "int64"},
+		{field: "{type: integer, format: uint32}", expected: "uint32"},
+		{field: "type: number", expected: "double"},
+		{field: "{type: number, format: float}", expected: "float"},
+		{field: "type: boolean", expected: "bool"},
+		{field: "type: [string, 'null']", expected: "string"},
+		{field: "type: ['null', integer]", expected: "int32"},
+		{field: "type: object", expected: ""},
+		{field: "type: array", expected: ""},
+		{field: "{}", expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.field, func(t *testing.T) {
+			field := yaml.MapSlice{}
+			if err := yaml.Unmarshal([]byte(c.field), &field); err != nil {
+				t.Fatal(err)
+			}
+
+			if result := specType(field); result != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpecFields(t *testing.T) {
+	definitions := yaml.MapSlice{}
+	err := yaml.Unmarshal([]byte(`
+Base:
+  required: [createdBy]
+  properties:
+    id:
+      type: string
+    createdBy:
+      type: string
+Category:
+  type: string
+  format: byte
+Pet:
+  required: [name]
+  properties:
+    id:
+      type: integer
+    name:
+      type: string
+    age:
+      type: integer
+      format: int64
+    category:
+      $ref: '#/definitions/Category'
+    createdAt:
+      type: string
+      readOnly: true
+    tags:
+      type: array
+      items:
+        type: string
+Dog:
+  allOf:
+    - $ref: '#/components/schemas/Base'
+    - required: [breed]
+      properties:
+        breed:
+          type: string
+        name:
+          type: string
+Empty:
+  properties:
+    id:
+      type: string
+    owner:
+      type: object
+`), &definitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type column struct {
+		Name     string
+		Protobuf string
+		Required bool
+		Index    int
+	}
+
+	cases := []struct {
+		schema   string
+		expected []column
+		err      bool
+	}{
+		{
+			schema: "Pet",
+			expected: []column{
+				{Name: "Name", Protobuf: "string", Required: true, Index: 2},
+				{Name: "Age", Protobuf: "int64", Index: 3},
+				{Name: "Category", Protobuf: "bytes", Index: 4},
+			},
+		},
+		{
+			schema: "Dog",
+			expected: []column{
+				{Name: "CreatedBy", Protobuf: "string", Required: true, Index: 2},
+				{Name: "Breed", Protobuf: "string", Required: true, Index: 3},
+				{Name: "Name", Protobuf: "string", Index: 4},
+			},
+		},
+		{
+			schema: "Empty",
+			err:    true,
+		},
+	}
+
+	mapType := project{}.types()
+	for _, c := range cases {
+		t.Run(c.schema, func(t *testing.T) {
+			fields, err := specFields(definitions, yamlMap(yamlLookup(definitions, c.schema)), mapType)
+			if c.err {
+				if err == nil {
+					t.Errorf("expected error, got %v", fields)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			columns := []column{}
+			for _, f := range fields {
+				columns = append(columns, column{Name: f.Name, Protobuf: f.ProtobufType, Required: f.IsRequired, Index: f.Index})
+			}
+
+			if !reflect.DeepEqual(columns, c.expected) {
+				t.Errorf("expected %+v, got %+v", c.expected, columns)
+			}
+		})
+	}
+}
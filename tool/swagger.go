@@ -0,0 +1,317 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bimalabs/generators"
+	"github.com/iancoleman/strcase"
+)
+
+type (
+	swaggerModule struct {
+		Name string
+		File string
+		Spec swagger
+	}
+
+	swagger struct {
+		Swagger             string                           `json:"swagger,omitempty"`
+		Info                swaggerInfo                      `json:"info"`
+		Tags                []swaggerTag                     `json:"tags,omitempty"`
+		Consumes            []string                         `json:"consumes,omitempty"`
+		Produces            []string                         `json:"produces,omitempty"`
+		Paths               map[string]map[string]*operation `json:"paths"`
+		Definitions         map[string]*schema               `json:"definitions,omitempty"`
+		SecurityDefinitions map[string]securityScheme        `json:"securityDefinitions,omitempty"`
+		Security            []map[string][]string            `json:"security,omitempty"`
+	}
+
+	swaggerInfo struct {
+		Title   string `json:"title,omitempty"`
+		Version string `json:"version,omitempty"`
+	}
+
+	swaggerTag struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+
+	securityScheme struct {
+		Type        string `json:"type,omitempty"`
+		Name        string `json:"name,omitempty"`
+		In          string `json:"in,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	operation struct {
+		OperationId string              `json:"operationId,omitempty"`
+		Summary     string              `json:"summary,omitempty"`
+		Description string              `json:"description,omitempty"`
+		Tags        []string            `json:"tags,omitempty"`
+		Parameters  []parameter         `json:"parameters,omitempty"`
+		Responses   map[string]response `json:"responses,omitempty"`
+	}
+
+	parameter struct {
+		Name             string   `json:"name"`
+		In               string   `json:"in"`
+		Description      string   `json:"description,omitempty"`
+		Required         bool     `json:"required,omitempty"`
+		Type             string   `json:"type,omitempty"`
+		Format           string   `json:"format,omitempty"`
+		Items            *schema  `json:"items,omitempty"`
+		CollectionFormat string   `json:"collectionFormat,omitempty"`
+		Enum             []string `json:"enum,omitempty"`
+		Schema           *schema  `json:"schema,omitempty"`
+	}
+
+	response struct {
+		Description string  `json:"description,omitempty"`
+		Schema      *schema `json:"schema,omitempty"`
+	}
+
+	schema struct {
+		Ref                  string             `json:"$ref,omitempty"`
+		Title                string             `json:"title,omitempty"`
+		Description          string             `json:"description,omitempty"`
+		Type                 string             `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Items                *schema            `json:"items,omitempty"`
+		Properties           map[string]*schema `json:"properties,omitempty"`
+		AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		Enum                 []string           `json:"enum,omitempty"`
+		MaxLength            int                `json:"maxLength,omitempty"`
+		ReadOnly             bool               `json:"readOnly,omitempty"`
+	}
+
+	rebuildReport struct {
+		Modules []string
+		Missing []string
+		Extra   []string
+		Added   []string
+		Removed []string
+	}
+
+	endpoint struct {
+		Name      string
+		Path      string
+		Method    string
+		Operation *operation
+	}
+)
+
+var (
+	methods     = []string{"get", "post", "put", "patch", "delete"}
+	suffixRegex = regexp.MustCompile(`[0-9]+$`)
+)
+
+func swaggers(workDir string) ([]swaggerModule, error) {
+	content, err := os.ReadFile(fmt.Sprintf("%s/swaggers/modules.json", workDir))
+	if err != nil {
+		return nil, err
+	}
+
+	registered := []generators.ModuleJson{}
+	if err = json.Unmarshal(content, &registered); err != nil {
+		return nil, err
+	}
+
+	modules := []swaggerModule{}
+	for _, v := range registered {
+		mUrl, err := url.Parse(v.Url)
+		if err != nil {
+			return nil, err
+		}
+
+		file := filepath.Base(mUrl.Path)
+		content, err := os.ReadFile(fmt.Sprintf("%s/swaggers/%s", workDir, file))
+		if err != nil {
+			return nil, err
+		}
+
+		spec := swagger{}
+		if err = json.Unmarshal(content, &spec); err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err.Error())
+		}
+
+		modules = append(modules, swaggerModule{
+			Name: v.Name,
+			File: strings.TrimSuffix(file, ".swagger.json"),
+			Spec: spec,
+		})
+	}
+
+	return modules, nil
+}
+
+func (p parameter) schema() *schema {
+	if p.Schema != nil {
+		return p.Schema
+	}
+
+	return &schema{
+		Type:   p.Type,
+		Format: p.Format,
+		Items:  p.Items,
+		Enum:   p.Enum,
+	}
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/definitions/")
+}
+
+func (s swagger) endpoints() []endpoint {
+	paths := make([]string, 0, len(s.Paths))
+	for k := range s.Paths {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+
+	endpoints := []endpoint{}
+	for _, path := range paths {
+		for _, method := range methods {
+			operation, ok := s.Paths[path][method]
+			if !ok {
+				continue
+			}
+
+			name := operation.OperationId
+			if index := strings.LastIndex(name, "_"); index >= 0 {
+				name = name[index+1:]
+			}
+
+			endpoints = append(endpoints, endpoint{
+				Name:      suffixRegex.ReplaceAllString(name, ""),
+				Path:      path,
+				Method:    method,
+				Operation: operation,
+			})
+		}
+	}
+
+	return endpoints
+}
+
+func example(s *schema, definitions map[string]*schema, depth int) interface{} {
+	if s == nil || depth > 5 {
+		return nil
+	}
+
+	if s.Ref != "" {
+		return example(definitions[refName(s.Ref)], definitions, depth+1)
+	}
+
+	switch s.Type {
+	case "string":
+		if len(s.Enum) > 0 {
+			return s.Enum[0]
+		}
+
+		switch s.Format {
+		case "int64", "uint64":
+			return "0"
+		case "byte":
+			return "Ynl0ZXM="
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		}
+
+		return "string"
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		item := example(s.Items, definitions, depth+1)
+		if item == nil {
+			return []interface{}{}
+		}
+
+		return []interface{}{item}
+	}
+
+	object := map[string]interface{}{}
+	for k, v := range s.Properties {
+		if v.ReadOnly {
+			continue
+		}
+
+		object[k] = example(v, definitions, depth+1)
+	}
+
+	return object
+}
+
+func rebuild(workDir string) (rebuildReport, error) {
+	report := rebuildReport{}
+	path := fmt.Sprintf("%s/swaggers/modules.json", workDir)
+
+	previous := []generators.ModuleJson{}
+	content, err := os.ReadFile(path)
+	if err == nil {
+		_ = json.Unmarshal(content, &previous)
+	}
+
+	listed := map[string]bool{}
+	for _, v := range previous {
+		listed[v.Name] = true
+	}
+
+	registry := parseModule(workDir)
+	registered := map[string]bool{}
+	modules := []generators.ModuleJson{}
+	for _, v := range registry.Config {
+		name := strings.TrimPrefix(v, "module:")
+		registered[name] = true
+
+		file := fmt.Sprintf("%s.swagger.json", name)
+		if _, err := os.Stat(fmt.Sprintf("%s/swaggers/%s", workDir, file)); err != nil {
+			report.Missing = append(report.Missing, file)
+
+			continue
+		}
+
+		model := strcase.ToCamel(name)
+		if d, ok := registry.Definitions[name]; ok && d.Name != "" {
+			model = d.Name
+		}
+
+		modules = append(modules, generators.ModuleJson{Name: model, Url: fmt.Sprintf("./%s", file)})
+		report.Modules = append(report.Modules, model)
+		if !listed[model] {
+			report.Added = append(report.Added, model)
+		}
+
+		delete(listed, model)
+	}
+
+	for _, v := range previous {
+		if listed[v.Name] {
+			report.Removed = append(report.Removed, v.Name)
+		}
+	}
+
+	files, _ := filepath.Glob(fmt.Sprintf("%s/swaggers/*.swagger.json", workDir))
+	for _, file := range files {
+		file = filepath.Base(file)
+		if !registered[strings.TrimSuffix(file, ".swagger.json")] {
+			report.Extra = append(report.Extra, file)
+		}
+	}
+
+	content, err = json.Marshal(modules)
+	if err != nil {
+		return report, err
+	}
+
+	return report, os.WriteFile(path, content, 0644)
+}
@@ -0,0 +1,134 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bimalabs/generators"
+)
+
+func TestRebuild(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		files    []string
+		previous string
+		report   rebuildReport
+		modules  []generators.ModuleJson
+	}{
+		{
+			name:    "registered modules",
+			config:  "modules:\n    - module:todo\n    - module:order_item\n",
+			files:   []string{"todo", "order_item"},
+			report:  rebuildReport{Modules: []string{"Todo", "OrderItem"}, Added: []string{"Todo", "OrderItem"}},
+			modules: []generators.ModuleJson{{Name: "Todo", Url: "./todo.swagger.json"}, {Name: "OrderItem", Url: "./order_item.swagger.json"}},
+		},
+		{
+			name:    "resolved name",
+			config:  "modules:\n    - module:person\ndefinitions:\n    person:\n        name: Person\n        plural: people\n",
+			files:   []string{"person"},
+			report:  rebuildReport{Modules: []string{"Person"}, Added: []string{"Person"}},
+			modules: []generators.ModuleJson{{Name: "Person", Url: "./person.swagger.json"}},
+		},
+		{
+			name:     "missing and extra swagger",
+			config:   "modules:\n    - module:todo\n    - module:category\n",
+			files:    []string{"todo", "legacy"},
+			previous: `[{"name":"Todo","url":"./todo.swagger.json"},{"name":"Legacy","url":"./legacy.swagger.json"}]`,
+			report:   rebuildReport{Modules: []string{"Todo"}, Missing: []string{"category.swagger.json"}, Extra: []string{"legacy.swagger.json"}, Removed: []string{"Legacy"}},
+			modules:  []generators.ModuleJson{{Name: "Todo", Url: "./todo.swagger.json"}},
+		},
+		{
+			name:    "no module",
+			config:  "modules: []\n",
+			report:  rebuildReport{},
+			modules: []generators.ModuleJson{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			for _, dir := range []string{"configs", "swaggers"} {
+				if err := os.Mkdir(fmt.Sprintf("%s/%s", workDir, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := os.WriteFile(fmt.Sprintf("%s/configs/modules.yaml", workDir), []byte(c.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, v := range c.files {
+				if err := os.WriteFile(fmt.Sprintf("%s/swaggers/%s.swagger.json", workDir, v), []byte("{}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if c.previous != "" {
+				if err := os.WriteFile(fmt.Sprintf("%s/swaggers/modules.json", workDir), []byte(c.previous), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			report, err := rebuild(workDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(report, c.report) {
+				t.Errorf("expected report %+v, got %+v", c.report, report)
+			}
+
+			content, err := os.ReadFile(fmt.Sprintf("%s/swaggers/modules.json", workDir))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			modules := []generators.ModuleJson{}
+			if err = json.Unmarshal(content, &modules); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(modules, c.modules) {
+				t.Errorf("expected modules %+v, got %+v", c.modules, modules)
+			}
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	spec := swagger{
+		Paths: map[string]map[string]*operation{
+			"/api/v1/todos/{id}": {
+				"delete": {OperationId: "Todos_Delete"},
+				"get":    {OperationId: "Todos_Get"},
+				"put":    {OperationId: "Todos_Update2"},
+			},
+			"/api/v1/todos": {
+				"post": {OperationId: "Todos_Create"},
+				"get":  {OperationId: "Todos_GetPaginated"},
+			},
+		},
+	}
+
+	expected := []string{
+		"get /api/v1/todos GetPaginated",
+		"post /api/v1/todos Create",
+		"get /api/v1/todos/{id} Get",
+		"put /api/v1/todos/{id} Update",
+		"delete /api/v1/todos/{id} Delete",
+	}
+
+	result := []string{}
+	for _, e := range spec.endpoints() {
+		result = append(result, fmt.Sprintf("%s %s %s", e.Method, e.Path, e.Name))
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,1706 @@
+package tool
+
+const (
+	connectionDic = `package connections
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/bimalabs/framework/v4"
+	"github.com/bimalabs/framework/v4/drivers"
+	"github.com/sarulabs/dingo/v4"
+	"gorm.io/gorm"
+)
+
+var {{.Name}} = []dingo.Def{
+	{
+		Name:  "bima:connection:{{.Connection}}",
+		Scope: bima.Application,
+		Build: func(driver *drivers.Factory) (*gorm.DB, error) {
+			port, _ := strconv.Atoi(os.Getenv("DB_{{.Env}}_PORT"))
+
+			return driver.Connect(
+				os.Getenv("DB_{{.Env}}_DRIVER"),
+				os.Getenv("DB_{{.Env}}_HOST"),
+				port,
+				os.Getenv("DB_{{.Env}}_USER"),
+				os.Getenv("DB_{{.Env}}_PASSWORD"),
+				os.Getenv("DB_{{.Env}}_NAME"),
+			), nil
+		},
+		Params: dingo.Params{
+			"0": dingo.Service("bima:driver:factory"),
+		},
+	},
+}
+`
+
+	connectionRepository = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bimalabs/framework/v4"
+	"github.com/bimalabs/framework/v4/configs"
+	"github.com/bimalabs/framework/v4/events"
+	"github.com/bimalabs/framework/v4/handlers"
+	"github.com/bimalabs/framework/v4/loggers"
+	"github.com/bimalabs/framework/v4/models"
+	"github.com/bimalabs/framework/v4/paginations"
+	"github.com/bimalabs/framework/v4/repositories"
+	"github.com/bimalabs/framework/v4/utils"
+	"github.com/sarulabs/dingo/v4"
+	"github.com/vcraescu/go-paginator/v2"
+	"gorm.io/gorm"
+)
+
+type (
+	Repository struct {
+		Database *gorm.DB
+		model    string
+	}
+
+	Adapter struct {
+		Debug      bool
+		Database   *gorm.DB
+		Dispatcher *events.Dispatcher
+	}
+
+	pager struct {
+		query *gorm.DB
+		total int64
+	}
+)
+
+var connection = dingo.Def{
+	Name:  "module:{{.ModuleLowercase}}:bima",
+	Scope: bima.Application,
+	Build: func(
+		env *configs.Env,
+		dispatcher *events.Dispatcher,
+		database *gorm.DB,
+		cache *utils.Cache,
+		validator utils.Validator,
+	) (bima.Module, error) {
+		repository := Repository{Database: database}
+		adapter := Adapter{Debug: env.Debug, Database: database, Dispatcher: dispatcher}
+
+		return bima.NewModule(env.Debug, handlers.New(env.Debug, dispatcher, &repository, &adapter), cache, validator, &paginations.Pagination{}), nil
+	},
+	Params: dingo.Params{
+		"0": dingo.Service("bima:config"),
+		"1": dingo.Service("bima:event:dispatcher"),
+		"2": dingo.Service("bima:connection:{{.Connection}}"),
+		"3": dingo.Service("bima:cache:memory"),
+		"4": dingo.Service("bima:validator"),
+	},
+}
+
+func (r *Repository) Model(model string) {
+	r.model = model
+}
+
+func (r *Repository) Transaction(f repositories.Transaction) error {
+	return r.Database.Transaction(func(tx *gorm.DB) error {
+		return f(&Repository{Database: tx, model: r.model})
+	})
+}
+
+func (r *Repository) Create(v interface{}) error {
+	return r.Database.Create(v).Error
+}
+
+func (r *Repository) Update(v interface{}) error {
+	return r.Database.Save(v).Error
+}
+
+func (r *Repository) Bind(v interface{}, id string) error {
+	return r.Database.Where("id = ?", id).First(v).Error
+}
+
+func (r *Repository) All(v interface{}) error {
+	return r.Database.Find(v).Error
+}
+
+func (r *Repository) FindBy(v interface{}, filters ...repositories.Filter) error {
+	db := r.Database
+	var filter strings.Builder
+	for _, f := range filters {
+		filter.Reset()
+		filter.WriteString(f.Field)
+		filter.WriteString(" ")
+		filter.WriteString(f.Operator)
+		filter.WriteString(" ?")
+
+		db = db.Where(filter.String(), f.Value)
+	}
+
+	return db.Find(v).Error
+}
+
+func (r *Repository) Delete(v interface{}, id string) error {
+	m := v.(models.GormModel)
+	if m.IsSoftDelete() {
+		r.Database.Save(v)
+
+		return r.Database.Where("id = ?", id).Delete(v).Error
+	}
+
+	return r.Database.Unscoped().Where("id = ?", id).Delete(v).Error
+}
+
+func (a *Adapter) CreateAdapter(ctx context.Context, paginator paginations.Pagination) paginator.Adapter {
+	if a.Database == nil {
+		loggers.Logger.Error(ctx, "adapter not configured properly")
+
+		return nil
+	}
+
+	event := events.GormPagination{
+		Query:   a.Database.Model(paginator.Model),
+		Filters: paginator.Filters,
+	}
+
+	if a.Debug {
+		loggers.Logger.Debug(ctx, "dispatching "+events.PaginationEvent.String())
+	}
+
+	_ = a.Dispatcher.Dispatch(events.PaginationEvent.String(), &event)
+
+	var total int64
+	event.Query.Count(&total)
+
+	return &pager{query: event.Query, total: total}
+}
+
+func (p *pager) Nums() (int64, error) {
+	return p.total, nil
+}
+
+func (p *pager) Slice(offset int, length int, data interface{}) error {
+	return p.query.Limit(length).Offset(offset).Find(data).Error
+}
+`
+
+	authorizationMiddleware = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/bimalabs/framework/v4/loggers"
+	"gopkg.in/yaml.v2"
+
+	"{{.PackageName}}/authorizations"
+)
+
+type Authorization struct {
+	once        sync.Once
+	permissions map[string][]string
+}
+
+func (a *Authorization) Attach(request *http.Request, response http.ResponseWriter) bool {
+	a.once.Do(a.load)
+
+	operation := a.operation(request)
+	if operation == "" {
+		return false
+	}
+
+	for _, role := range authorizations.Roles(request.Context()) {
+		for _, v := range a.permissions[operation] {
+			if v == "*" || v == role {
+				return false
+			}
+		}
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusForbidden)
+	_, _ = response.Write([]byte(` + "`" + `{"code":7,"message":"Permission denied","details":[]}` + "`" + `))
+
+	return true
+}
+
+func (a *Authorization) Priority() int {
+	return -1
+}
+
+func (a *Authorization) operation(request *http.Request) string {
+	path := strings.TrimSuffix(request.URL.Path, "/")
+	switch path {
+	case "{{.Base}}":
+		switch request.Method {
+		case http.MethodGet:
+			return "list"
+		case http.MethodPost:
+			return "create"
+		}
+
+		return ""
+	case "{{.Base}}/batch":
+		switch request.Method {
+		case http.MethodPost:
+			return "create"
+		case http.MethodPut, http.MethodPatch:
+			return "update"
+		}
+
+		return ""
+	case "{{.Base}}/batch/delete":
+		if request.Method == http.MethodPost {
+			return "delete"
+		}
+
+		return ""
+	case "{{.Base}}/export":
+		if request.Method == http.MethodGet {
+			return "list"
+		}
+
+		return ""
+	case "{{.Base}}/import":
+		if request.Method == http.MethodPost {
+			return "create"
+		}
+
+		return ""
+	case "{{.Base}}/trashed":
+		if request.Method == http.MethodGet {
+			return "list"
+		}
+
+		return ""
+	}
+
+	id := strings.TrimPrefix(path, "{{.Base}}/")
+	if restore := strings.TrimSuffix(id, "/restore"); restore != id && restore != "" && !strings.Contains(restore, "/") {
+		if request.Method == http.MethodPost {
+			return "delete"
+		}
+
+		return ""
+	}
+
+	if id == path || id == "" || strings.Contains(id, "/") {
+		return ""
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		return "get"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	}
+
+	return ""
+}
+
+func (a *Authorization) load() {
+	ctx := context.WithValue(context.Background(), loggers.ScopeKey, "{{.ModuleLowercase}}:authorization")
+	config := struct {
+		Definitions map[string]struct {
+			Permissions map[string][]string ` + "`" + `yaml:"permissions"` + "`" + `
+		} ` + "`" + `yaml:"definitions"` + "`" + `
+	}{}
+
+	content, err := os.ReadFile("configs/modules.yaml")
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return
+	}
+
+	if err = yaml.Unmarshal(content, &config); err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return
+	}
+
+	a.permissions = config.Definitions["{{.ModuleLowercase}}"].Permissions
+}
+`
+
+	authorizationRoles = `package authorizations
+
+import (
+	"context"
+	"net/http"
+)
+
+type rolesKey struct{}
+
+// Grant attach roles to request, call it from middleware that authenticate the request and has higher priority than module authorization
+func Grant(request *http.Request, roles ...string) {
+	*request = *request.WithContext(WithRoles(request.Context(), roles...))
+}
+
+func WithRoles(ctx context.Context, roles ...string) context.Context {
+	granted := append([]string{}, Roles(ctx)...)
+
+	return context.WithValue(ctx, rolesKey{}, append(granted, roles...))
+}
+
+func Roles(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+
+	return roles
+}
+`
+
+	queryListener = `package {{.ModulePluralLowercase}}
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bimalabs/framework/v4"
+	"github.com/bimalabs/framework/v4/events"
+	"github.com/bimalabs/framework/v4/paginations"
+	"{{.PackageName}}/protos/builds"
+	"{{.PackageName}}/queries"
+)
+
+type Query struct {
+}
+
+var columns = map[string]queries.Column{
+{{- range .Queries}}
+	"{{.Column}}": {Operators: []string{ {{- range $i, $v := .Operators}}{{if $i}}, {{end}}"{{$v}}"{{end -}} }, Sortable: {{.Sortable}}, Searchable: {{.Searchable}}},
+{{- end}}
+}
+
+func (m *Module) query(paginator *paginations.Pagination, r *grpcs.{{.Module}}PaginationRequest) error {
+	if len(r.Fields) == 0 || len(r.Fields) != len(r.Values) {
+		paginator.Filters = nil
+	}
+
+	paginator.Search = r.Search
+	for _, v := range r.Filters {
+		filter := strings.SplitN(v, ":", 3)
+		if len(filter) != 3 {
+			return fmt.Errorf("invalid filter '%s', use <field>:<operator>:<value>", v)
+		}
+
+		if !queries.Allowed(columns, filter[0], filter[1]) {
+			return fmt.Errorf("filter '%s' using '%s' operator is not allowed", filter[0], filter[1])
+		}
+
+		paginator.Filters = append(paginator.Filters, paginations.Filter{Field: filter[0] + ":" + filter[1], Value: filter[2]})
+	}
+
+	if r.Sort != "" {
+		if _, ok := queries.Order(columns, r.Sort); !ok {
+			return fmt.Errorf("sort by '%s' is not allowed", strings.TrimPrefix(r.Sort, "-"))
+		}
+
+		paginator.Filters = append(paginator.Filters, paginations.Filter{Field: ":sort", Value: r.Sort})
+	}
+
+	if r.Search != "" {
+		paginator.Filters = append(paginator.Filters, paginations.Filter{Field: ":search", Value: r.Search})
+	}
+
+	return nil
+}
+
+func (q *Query) Handle(event interface{}) interface{} {
+	e, ok := event.(*events.GormPagination)
+	if !ok {
+		return event
+	}
+
+	switch e.Query.Statement.Model.(type) {
+	case {{.Module}}, *{{.Module}}:
+	default:
+		return event
+	}
+
+	filters := make([]paginations.Filter, 0, len(e.Filters))
+	for _, v := range e.Filters {
+		switch {
+		case v.Field == ":sort":
+			if order, ok := queries.Order(columns, v.Value); ok {
+				e.Query = e.Query.Order(order)
+			}
+		case v.Field == ":search":
+			if condition, values := queries.Search(columns, v.Value); condition != "" {
+				e.Query = e.Query.Where(condition, values...)
+			}
+		case strings.Contains(v.Field, ":"):
+			condition, operator, ok := queries.Condition(columns, v.Field)
+			if !ok {
+				continue
+			}
+
+			switch operator {
+			case "like":
+				e.Query = e.Query.Where(condition, "%"+v.Value+"%")
+			case "in":
+				e.Query = e.Query.Where(condition, strings.Split(v.Value, ","))
+			default:
+				e.Query = e.Query.Where(condition, v.Value)
+			}
+		default:
+			filters = append(filters, v)
+		}
+	}
+
+	e.Filters = filters
+
+	return e
+}
+
+func (q *Query) Listen() string {
+	return events.PaginationEvent.String()
+}
+
+func (q *Query) Priority() int {
+	return bima.HighestPriority + 1
+}
+`
+
+	paginationRequest = `message {{.Module}}PaginationRequest {
+    int32 page = 1;
+    int32 limit = 2;
+    repeated string fields = 3;
+    repeated string values = 4;
+    repeated string filters = 5 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {description: "Filter using <field>:<operator>:<value>, allowed: {{.Filters}}"}];
+    string sort = 6 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {description: "Sort using <field> or -<field> for descending, allowed: {{.Sorts}}"}];
+    string search = 7 [(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = {description: "Search in: {{.Searches}}"}];
+}
+
+`
+
+	eventPublisher = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+
+	"github.com/bimalabs/framework/v4/loggers"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	Created = "{{.ModuleLowercase}}.created"
+	Updated = "{{.ModuleLowercase}}.updated"
+	Deleted = "{{.ModuleLowercase}}.deleted"
+)
+
+func (m *Module) publish(ctx context.Context, topic string, payload proto.Message) {
+	if m.Messenger == nil {
+		return
+	}
+
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return
+	}
+
+	if err = m.Messenger.Publish(topic, data); err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+	}
+}
+`
+
+	eventSubscriber = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+
+	"github.com/bimalabs/framework/v4/loggers"
+	"github.com/bimalabs/framework/v4/messengers"
+	"{{.PackageName}}/protos/builds"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) Consume(messenger *messengers.Messenger) {
+	if messenger == nil {
+		return
+	}
+
+	s.subscribe(messenger, Created, func(data []byte) error {
+		payload := grpcs.{{.Module}}Created{}
+		if err := proto.Unmarshal(data, &payload); err != nil {
+			return err
+		}
+
+		// TODO
+
+		return nil
+	})
+
+	s.subscribe(messenger, Updated, func(data []byte) error {
+		payload := grpcs.{{.Module}}Updated{}
+		if err := proto.Unmarshal(data, &payload); err != nil {
+			return err
+		}
+
+		// TODO
+
+		return nil
+	})
+
+	s.subscribe(messenger, Deleted, func(data []byte) error {
+		payload := grpcs.{{.Module}}Deleted{}
+		if err := proto.Unmarshal(data, &payload); err != nil {
+			return err
+		}
+
+		// TODO
+
+		return nil
+	})
+}
+
+func (s *Server) subscribe(messenger *messengers.Messenger, topic string, handle func(data []byte) error) {
+	ctx := context.WithValue(context.Background(), loggers.ScopeKey, topic)
+	messages, err := messenger.Consume(topic)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return
+	}
+
+	go func() {
+		for message := range messages {
+			if err := handle(message.Payload); err != nil {
+				loggers.Logger.Error(ctx, err.Error())
+				message.Nack()
+
+				continue
+			}
+
+			message.Ack()
+		}
+	}()
+}
+`
+
+	eventMessage = `message {{.Module}}Created {
+    {{.Module}} data = 1;
+}
+
+message {{.Module}}Updated {
+    {{.Module}} data = 1;
+}
+
+message {{.Module}}Deleted {
+    string id = 1;
+}
+
+`
+
+	batchHandler = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+{{- if .Tenant}}
+	"errors"
+{{- end}}
+	"fmt"
+
+	"github.com/bimalabs/framework/v4"
+	"github.com/bimalabs/framework/v4/loggers"
+	"github.com/bimalabs/framework/v4/models"
+	"github.com/bimalabs/framework/v4/repositories"
+	"github.com/jinzhu/copier"
+	"{{.PackageName}}/protos/builds"
+{{- if .Tenant}}
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+{{- end}}
+)
+
+func (m *Module) BatchCreate(ctx context.Context, r *grpcs.{{.Module}}BatchRequest) (*grpcs.{{.Module}}BatchResponse, error) {
+	ctx = context.WithValue(ctx, "scope", "{{.ModuleLowercase}}")
+{{- if .Tenant}}
+	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+{{end}}
+	records := make([]*{{.Module}}, len(r.Data))
+	results := make([]*grpcs.{{.Module}}BatchResult, len(r.Data))
+	for k, d := range r.Data {
+		records[k] = m.model()
+		copier.Copy(records[k], d)
+
+		results[k] = &grpcs.{{.Module}}BatchResult{Index: int32(k), Data: d}
+		if message, err := m.Validate(records[k]); err != nil {
+			results[k].Message = message
+		}
+	}
+
+	response := m.batch(ctx, {{if .Tenant}}tenant, {{end}}results, func(repository repositories.Repository, k int) error {
+{{- if .Tenant}}
+		records[k].TenantId = tenant
+{{- end}}
+		if err := repository.Create(records[k]); err != nil {
+			return err
+		}
+
+		results[k].Id = records[k].Id
+		results[k].Data.Id = records[k].Id
+
+		return nil
+	})
+{{- if .Events}}
+
+	for _, v := range response.Data {
+		if v.Success {
+			m.publish(ctx, Created, &grpcs.{{.Module}}Created{Data: v.Data})
+		}
+	}
+{{- end}}
+
+	return response, nil
+}
+
+func (m *Module) BatchUpdate(ctx context.Context, r *grpcs.{{.Module}}BatchRequest) (*grpcs.{{.Module}}BatchResponse, error) {
+	ctx = context.WithValue(ctx, "scope", "{{.ModuleLowercase}}")
+{{- if .Tenant}}
+	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+{{end}}
+	records := make([]*{{.Module}}, len(r.Data))
+	results := make([]*grpcs.{{.Module}}BatchResult, len(r.Data))
+	for k, d := range r.Data {
+		records[k] = m.model()
+		copier.Copy(records[k], d)
+
+		results[k] = &grpcs.{{.Module}}BatchResult{Index: int32(k), Id: d.Id, Data: d}
+		if message, err := m.Validate(records[k]); err != nil {
+			results[k].Message = message
+		}
+	}
+
+	response := m.batch(ctx, {{if .Tenant}}tenant, {{end}}results, func(repository repositories.Repository, k int) error {
+		hold := m.model()
+		if err := repository.Bind(hold, results[k].Id); err != nil {
+			results[k].Message = fmt.Sprintf("Data with ID '%s' not found.", results[k].Id)
+
+			return err
+		}
+{{- if .Tenant}}
+
+		if hold.TenantId != tenant {
+			results[k].Message = fmt.Sprintf("Data with ID '%s' not found.", results[k].Id)
+
+			return errors.New(results[k].Message)
+		}
+{{- end}}
+
+		records[k].Id = results[k].Id
+{{- if .Tenant}}
+		records[k].TenantId = tenant
+{{- end}}
+		records[k].SetCreatedBy(hold.CreatedBy.String)
+		records[k].SetCreatedAt(hold.CreatedAt.Time)
+
+		return repository.Update(records[k])
+	})
+{{- if .Events}}
+
+	for _, v := range response.Data {
+		if v.Success {
+			m.publish(ctx, Updated, &grpcs.{{.Module}}Updated{Data: v.Data})
+		}
+	}
+{{- end}}
+
+	return response, nil
+}
+
+func (m *Module) BatchDelete(ctx context.Context, r *grpcs.{{.Module}}BatchDeleteRequest) (*grpcs.{{.Module}}BatchResponse, error) {
+	ctx = context.WithValue(ctx, "scope", "{{.ModuleLowercase}}")
+{{- if .Tenant}}
+	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+{{end}}
+	results := make([]*grpcs.{{.Module}}BatchResult, len(r.Ids))
+	for k, id := range r.Ids {
+		results[k] = &grpcs.{{.Module}}BatchResult{Index: int32(k), Id: id}
+	}
+
+	response := m.batch(ctx, {{if .Tenant}}tenant, {{end}}results, func(repository repositories.Repository, k int) error {
+		v := m.model()
+		if err := repository.Bind(v, results[k].Id); err != nil {
+			results[k].Message = fmt.Sprintf("Data with ID '%s' not found.", results[k].Id)
+
+			return err
+		}
+{{- if .Tenant}}
+
+		if v.TenantId != tenant {
+			results[k].Message = fmt.Sprintf("Data with ID '%s' not found.", results[k].Id)
+
+			return errors.New(results[k].Message)
+		}
+{{- end}}
+
+		return repository.Delete(v, results[k].Id)
+	})
+{{- if .Events}}
+
+	for _, v := range response.Data {
+		if v.Success {
+			m.publish(ctx, Deleted, &grpcs.{{.Module}}Deleted{Id: v.Id})
+		}
+	}
+{{- end}}
+
+	return response, nil
+}
+
+func (m *Module) batch(ctx context.Context, {{if .Tenant}}tenant string, {{end}}results []*grpcs.{{.Module}}BatchResult, process func(repository repositories.Repository, k int) error) *grpcs.{{.Module}}BatchResponse {
+	response := &grpcs.{{.Module}}BatchResponse{Data: results}
+	for _, v := range results {
+		if v.Message != "" {
+			return response
+		}
+	}
+
+	err := m.Handler().Repository().Transaction(func(repository repositories.Repository) error {
+		for k, v := range results {
+			if err := process(repository, k); err != nil {
+				if v.Message == "" {
+					v.Message = "Internal server error"
+				}
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return response
+	}
+
+	for _, v := range results {
+		v.Success = true
+		m.Cache().Invalidate({{if .Tenant}}scoped(tenant, v.Id){{else}}v.Id{{end}})
+	}
+
+	return response
+}
+
+func (m *Module) model() *{{.Module}} {
+	return &{{.Module}}{
+		GormModel: &bima.GormModel{
+			GormBase: models.GormBase{Env: m.Model.Env},
+		},
+	}
+}
+`
+
+	batchMessage = `message {{.Module}}BatchRequest {
+    repeated {{.Module}} data = 1;
+}
+
+message {{.Module}}BatchDeleteRequest {
+    repeated string ids = 1;
+}
+
+message {{.Module}}BatchResult {
+    int32 index = 1;
+    string id = 2;
+    bool success = 3;
+    string message = 4;
+    {{.Module}} data = 5;
+}
+
+message {{.Module}}BatchResponse {
+    repeated {{.Module}}BatchResult data = 1;
+}
+
+`
+
+	batchService = `
+    rpc BatchCreate ({{.Module}}BatchRequest) returns ({{.Module}}BatchResponse) {
+        option (google.api.http) = {
+            post: "{{.Base}}/batch"
+            body: "*"
+        };
+    }
+
+    rpc BatchUpdate ({{.Module}}BatchRequest) returns ({{.Module}}BatchResponse) {
+        option (google.api.http) = {
+            put: "{{.Base}}/batch"
+            body: "*"
+
+            additional_bindings {
+                patch: "{{.Base}}/batch"
+                body: "*"
+            }
+        };
+    }
+
+    rpc BatchDelete ({{.Module}}BatchDeleteRequest) returns ({{.Module}}BatchResponse) {
+        option (google.api.http) = {
+            post: "{{.Base}}/batch/delete"
+            body: "*"
+        };
+    }
+`
+
+	csvRoute = `package {{.ModulePluralLowercase}}
+
+import (
+{{range .Imports}}	"{{.}}"
+{{end}}
+	"github.com/bimalabs/framework/v4/loggers"
+	"github.com/bimalabs/framework/v4/middlewares"
+	"github.com/bimalabs/framework/v4/paginations"
+	"github.com/goccy/go-json"
+	"{{.PackageName}}/protos/builds"
+	"google.golang.org/grpc"
+{{- if .Tenant}}
+	"google.golang.org/grpc/metadata"
+{{- end}}
+	"google.golang.org/grpc/status"
+)
+
+const chunk = 500
+
+var headers = []string{"id"{{range .Fields}}, "{{.Header}}"{{end}}}
+
+type (
+	Export struct {
+		Module *Module
+	}
+
+	Import struct {
+		Module *Module
+	}
+
+	row struct {
+		Row     int    ` + "`" + `json:"row"` + "`" + `
+		Id      string ` + "`" + `json:"id,omitempty"` + "`" + `
+		Success bool   ` + "`" + `json:"success"` + "`" + `
+		Message string ` + "`" + `json:"message,omitempty"` + "`" + `
+	}
+
+	report struct {
+		Total   int   ` + "`" + `json:"total"` + "`" + `
+		Success int   ` + "`" + `json:"success"` + "`" + `
+		Failed  int   ` + "`" + `json:"failed"` + "`" + `
+		Rows    []row ` + "`" + `json:"rows"` + "`" + `
+	}
+)
+
+func (e *Export) Path() string {
+	return "{{.Path}}/export"
+}
+
+func (e *Export) Method() string {
+	return http.MethodGet
+}
+
+func (e *Export) SetClient(client *grpc.ClientConn) {}
+
+func (e *Export) Middlewares() []middlewares.Middleware {
+	return nil
+}
+
+func (e *Export) Handle(response http.ResponseWriter, request *http.Request, params map[string]string) {
+	ctx := context.WithValue(request.Context(), loggers.ScopeKey, "{{.ModuleLowercase}}:export")
+
+	response.Header().Set("Content-Type", "text/csv")
+	response.Header().Set("Content-Disposition", "attachment; filename={{.ModulePluralLowercase}}.csv")
+
+	writer := csv.NewWriter(response)
+	if err := writer.Write(headers); err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return
+	}
+
+	for page := 1; page != -1; {
+		paginator := paginations.Pagination{
+			Limit: chunk,
+			Page:  page,
+			Model: *e.Module.Model,
+			Table: e.Module.Model.TableName(),
+		}
+{{- if .Tenant}}
+		paginator.Filters = append(paginator.Filters, paginations.Filter{Field: tenantFilter, Value: request.Header.Get(TenantHeader)})
+{{- end}}
+
+		records := make([]*grpcs.{{.Module}}, 0, chunk)
+		metadata := e.Module.Handler().Paginate(&paginator, &records)
+		for _, v := range records {
+			if err := writer.Write([]string{v.Id{{range .Fields}}, {{.Format}}{{end}}}); err != nil {
+				loggers.Logger.Error(ctx, err.Error())
+
+				return
+			}
+		}
+
+		writer.Flush()
+		if flusher, ok := response.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		page = metadata.Next
+	}
+}
+
+func (i *Import) Path() string {
+	return "{{.Path}}/import"
+}
+
+func (i *Import) Method() string {
+	return http.MethodPost
+}
+
+func (i *Import) SetClient(client *grpc.ClientConn) {}
+
+func (i *Import) Middlewares() []middlewares.Middleware {
+	return nil
+}
+
+func (i *Import) Handle(response http.ResponseWriter, request *http.Request, params map[string]string) {
+	ctx := context.WithValue(request.Context(), loggers.ScopeKey, "{{.ModuleLowercase}}:import")
+{{- if .Tenant}}
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tenantKey, request.Header.Get(TenantHeader)))
+{{- end}}
+
+	file, _, err := request.FormFile("file")
+	if err != nil {
+		reject(response, "File is required")
+
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	columns, err := reader.Read()
+	if err != nil {
+		reject(response, "Invalid CSV header")
+
+		return
+	}
+
+	result := report{Rows: []row{}}
+	for line := 2; ; line++ {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		result.Total++
+		item := row{Row: line}
+		if err == nil {
+			item.Id, err = i.create(ctx, columns, values)
+		}
+
+		if err != nil {
+			item.Message = err.Error()
+			result.Failed++
+		} else {
+			item.Success = true
+			result.Success++
+		}
+
+		result.Rows = append(result.Rows, item)
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(response).Encode(result)
+}
+
+func (i *Import) create(ctx context.Context, columns []string, values []string) (string, error) {
+	record := grpcs.{{.Module}}{}
+	for k, column := range columns {
+		if k >= len(values) || values[k] == "" {
+			continue
+		}
+
+		value := values[k]
+		switch column {
+{{range .Fields}}		case "{{.Header}}":
+{{.Parse}}
+{{end}}		}
+	}
+
+	created, err := i.Module.Create(ctx, &record)
+	if err != nil {
+		return "", errors.New(status.Convert(err).Message())
+	}
+
+	return created.Id, nil
+}
+
+func reject(response http.ResponseWriter, message string) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(response).Encode(map[string]interface{}{
+		"code":    3,
+		"message": message,
+		"details": []interface{}{},
+	})
+}
+`
+
+	tenantScope = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bimalabs/framework/v4"
+	"github.com/bimalabs/framework/v4/events"
+	"github.com/bimalabs/framework/v4/paginations"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	TenantHeader = "X-Tenant-Id"
+	TenantColumn = "tenant_id"
+
+	tenantKey    = "x-tenant-id"
+	tenantFilter = ":tenant"
+)
+
+type (
+	Tenant struct {
+	}
+
+	TenantScope struct {
+	}
+)
+
+func (t *Tenant) Attach(request *http.Request, response http.ResponseWriter) bool {
+	path := strings.TrimSuffix(request.URL.Path, "/")
+	if path != "{{.Base}}" && !strings.HasPrefix(path, "{{.Base}}/") {
+		return false
+	}
+
+	tenant := request.Header.Get(TenantHeader)
+	if tenant == "" {
+		response.Header().Set("Content-Type", "application/json")
+		response.WriteHeader(http.StatusBadRequest)
+		_, _ = response.Write([]byte(` + "`" + `{"code":3,"message":"Tenant is required","details":[]}` + "`" + `))
+
+		return true
+	}
+
+	request.Header.Set(runtime.MetadataHeaderPrefix+TenantHeader, tenant)
+
+	return false
+}
+
+func (t *Tenant) Priority() int {
+	return 0
+}
+
+func (t *TenantScope) Handle(event interface{}) interface{} {
+	e, ok := event.(*events.GormPagination)
+	if !ok {
+		return event
+	}
+
+	switch e.Query.Statement.Model.(type) {
+	case {{.Module}}, *{{.Module}}:
+	default:
+		return event
+	}
+
+	filters := make([]paginations.Filter, 0, len(e.Filters))
+	for _, v := range e.Filters {
+		if v.Field == tenantFilter {
+			e.Query = e.Query.Where(TenantColumn+" = ?", v.Value)
+
+			continue
+		}
+
+		filters = append(filters, v)
+	}
+
+	e.Filters = filters
+
+	return e
+}
+
+func (t *TenantScope) Listen() string {
+	return events.PaginationEvent.String()
+}
+
+func (t *TenantScope) Priority() int {
+	return bima.HighestPriority + 2
+}
+
+func (m *Module) tenant(ctx context.Context) (string, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenantKey); len(values) > 0 && values[0] != "" {
+			return values[0], nil
+		}
+	}
+
+	return "", errors.New("Tenant is required")
+}
+
+func scoped(tenant string, id string) string {
+	var key strings.Builder
+	key.WriteString(tenant)
+	key.WriteString(":")
+	key.WriteString(id)
+
+	return key.String()
+}
+`
+
+	trashHandler = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bimalabs/framework/v4"
+{{- if not .Connection}}
+	"github.com/bimalabs/framework/v4/configs"
+{{- end}}
+	"github.com/bimalabs/framework/v4/events"
+	"github.com/bimalabs/framework/v4/loggers"
+	"github.com/bimalabs/framework/v4/paginations"
+	"github.com/jinzhu/copier"
+	"{{.PackageName}}/protos/builds"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const trashedFilter = ":trashed"
+
+type Trash struct {
+}
+
+func (m *Module) GetTrashed(ctx context.Context, r *grpcs.PaginationRequest) (*grpcs.{{.Module}}PaginatedResponse, error) {
+	ctx = context.WithValue(ctx, "scope", "{{.ModuleLowercase}}")
+{{- if .Tenant}}
+	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+{{end}}
+	reqeust := paginations.Request{}
+	copier.Copy(&reqeust, r)
+
+	paginator := paginations.Pagination{
+		Model: *m.Model,
+		Table: m.Model.TableName(),
+	}
+
+	paginator.Handle(reqeust)
+	paginator.Filters = append(paginator.Filters, paginations.Filter{Field: trashedFilter, Value: "true"})
+{{- if .Tenant}}
+	paginator.Filters = append(paginator.Filters, paginations.Filter{Field: tenantFilter, Value: tenant})
+{{- end}}
+
+	records := make([]*grpcs.{{.Module}}, 0, paginator.Limit)
+	metadata := m.Handler().Paginate(&paginator, &records)
+
+	return &grpcs.{{.Module}}PaginatedResponse{
+		Data: records,
+		Meta: &grpcs.PaginationMetadata{
+			Page:     int32(metadata.Page),
+			Previous: int32(metadata.Previous),
+			Next:     int32(metadata.Next),
+			Limit:    int32(metadata.Limit),
+			Total:    int32(metadata.Total),
+		},
+	}, nil
+}
+
+func (m *Module) Restore(ctx context.Context, r *grpcs.{{.Module}}) (*grpcs.{{.Module}}, error) {
+	ctx = context.WithValue(ctx, "scope", "{{.ModuleLowercase}}")
+{{- if .Tenant}}
+	tenant, err := m.tenant(ctx)
+	if err != nil {
+		loggers.Logger.Error(ctx, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+{{end}}
+	query := m.database().Unscoped().Table(m.Model.TableName()).Where("id = ? AND deleted_at IS NOT NULL", r.Id)
+{{- if .Tenant}}
+	query = query.Where(TenantColumn+" = ?", tenant)
+{{- end}}
+
+	result := query.Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"deleted_by": nil,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		loggers.Logger.Error(ctx, result.Error.Error())
+
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Data with ID '%s' not found.", r.Id))
+	}
+
+	m.Cache().Invalidate({{if .Tenant}}scoped(tenant, r.Id){{else}}r.Id{{end}})
+
+	return m.Get(ctx, &grpcs.{{.Module}}{Id: r.Id})
+}
+
+func (m *Module) database() *gorm.DB {
+{{- if .Connection}}
+	return m.Handler().Repository().(*Repository).Database
+{{- else}}
+	return configs.Database
+{{- end}}
+}
+
+func (t *Trash) Handle(event interface{}) interface{} {
+	e, ok := event.(*events.GormPagination)
+	if !ok {
+		return event
+	}
+
+	switch e.Query.Statement.Model.(type) {
+	case {{.Module}}, *{{.Module}}:
+	default:
+		return event
+	}
+
+	filters := make([]paginations.Filter, 0, len(e.Filters))
+	for _, v := range e.Filters {
+		if v.Field == trashedFilter {
+			e.Query = e.Query.Unscoped().Where("deleted_at IS NOT NULL")
+
+			continue
+		}
+
+		filters = append(filters, v)
+	}
+
+	e.Filters = filters
+
+	return e
+}
+
+func (t *Trash) Listen() string {
+	return events.PaginationEvent.String()
+}
+
+func (t *Trash) Priority() int {
+	return bima.HighestPriority + 3
+}
+`
+
+	trashService = `
+    rpc GetTrashed (PaginationRequest) returns ({{.Module}}PaginatedResponse) {
+        option (google.api.http) = {
+            get: "{{.Base}}/trashed"
+        };
+    }
+
+    rpc Restore ({{.Module}}) returns ({{.Module}}) {
+        option (google.api.http) = {
+            post: "{{.Base}}/{id}/restore"
+        };
+    }
+`
+
+	encryptedField = `package {{.ModulePluralLowercase}}
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/bimalabs/framework/v4/loggers"
+	"{{.PackageName}}/protos/builds"
+)
+
+type Encrypted string
+
+var (
+	once      sync.Once
+	aead      cipher.AEAD
+	aeadError error
+)
+
+func (e Encrypted) Value() (driver.Value, error) {
+	if e == "" {
+		return "", nil
+	}
+
+	return encrypt(string(e))
+}
+
+func (e *Encrypted) Scan(value interface{}) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		*e = ""
+
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("unsupported encrypted value %T", value)
+	}
+
+	plain, err := decrypt(text)
+	if err != nil {
+		return err
+	}
+
+	*e = Encrypted(plain)
+
+	return nil
+}
+
+func reveal(records []*grpcs.{{.Module}}) {
+	ctx := context.WithValue(context.Background(), loggers.ScopeKey, "{{.ModuleLowercase}}:encryption")
+	for _, v := range records {
+{{- range .Encrypted}}
+		if plain, err := decrypt(v.{{.}}); err != nil {
+			loggers.Logger.Error(ctx, err.Error())
+			v.{{.}} = ""
+		} else {
+			v.{{.}} = plain
+		}
+{{- end}}
+	}
+}
+
+func encrypt(plain string) (string, error) {
+	gcm, err := key()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plain), nil)), nil
+}
+
+func decrypt(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	gcm, err := key()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted value")
+	}
+
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+func key() (cipher.AEAD, error) {
+	once.Do(func() {
+		secret := os.Getenv("ENCRYPTION_KEY")
+		if secret == "" {
+			secret = os.Getenv("APP_SECRET")
+		}
+
+		if secret == "" {
+			aeadError = errors.New("ENCRYPTION_KEY or APP_SECRET is not configured")
+
+			return
+		}
+
+		hash := sha256.Sum256([]byte(secret))
+		block, err := aes.NewCipher(hash[:])
+		if err != nil {
+			aeadError = err
+
+			return
+		}
+
+		aead, aeadError = cipher.NewGCM(block)
+	})
+
+	return aead, aeadError
+}
+`
+
+	clientPackage = `// Code generated by bima. DO NOT EDIT.
+package {{.Name}}
+
+import (
+	"context"
+	"time"
+
+	"{{.Package}}/protos/builds"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	Option func(client *Client)
+
+	Client struct {
+		client  grpcs.{{.Service}}Client
+		timeout time.Duration
+		retries int
+		backoff time.Duration
+	}
+)
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		client.timeout = timeout
+	}
+}
+
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(client *Client) {
+		client.retries = retries
+		client.backoff = backoff
+	}
+}
+
+func New(conn *grpc.ClientConn, options ...Option) *Client {
+	client := &Client{
+		client:  grpcs.New{{.Service}}Client(conn),
+		timeout: 5 * time.Second,
+		retries: 3,
+		backoff: 100 * time.Millisecond,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+{{range .Methods}}
+func (c *Client) {{.Name}}(ctx context.Context, {{if .ById}}id string{{else}}request *grpcs.{{.Request}}{{end}}, options ...grpc.CallOption) (*grpcs.{{.Response}}, error) {
+	var response *grpcs.{{.Response}}
+	err := c.{{if .Idempotent}}call{{else}}attempt{{end}}(ctx, func(ctx context.Context) (err error) {
+		response, err = c.client.{{.Name}}(ctx, {{if .ById}}&grpcs.{{.Request}}{Id: id}{{else}}request{{end}}, options...)
+
+		return err
+	})
+
+	return response, err
+}
+{{end}}
+func (c *Client) call(ctx context.Context, invoke func(ctx context.Context) error) error {
+	var err error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.backoff * time.Duration(attempt)):
+			}
+		}
+
+		err = c.attempt(ctx, invoke)
+		if !retryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (c *Client) attempt(ctx context.Context, invoke func(ctx context.Context) error) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	return invoke(ctx)
+}
+
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+
+	return false
+}
+`
+
+	sdkClient = `// Code generated by bima. DO NOT EDIT.
+export interface Config {
+  baseUrl: string;
+  headers: Record<string, string>;
+  fetch?: typeof fetch;
+}
+
+export const config: Config = {
+  baseUrl: '{{.BaseUrl}}',
+  headers: {},
+};
+
+export function configure(options: Partial<Config>): void {
+  Object.assign(config, options);
+}
+
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: unknown;
+
+  constructor(status: number, body: unknown) {
+    const message = typeof body === 'object' && body !== null && 'message' in body ? String((body as { message: unknown }).message) : 'HTTP ' + status;
+    super(message);
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export interface RequestOptions {
+  query?: object;
+  body?: unknown;
+  init?: RequestInit;
+}
+
+export async function request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+  const search = new URLSearchParams();
+  for (const [key, value] of Object.entries(options.query ?? {})) {
+    if (value === undefined || value === null) {
+      continue;
+    }
+
+    for (const item of Array.isArray(value) ? value : [value]) {
+      search.append(key, String(item));
+    }
+  }
+
+  const headers = new Headers(config.headers);
+  headers.set('Accept', 'application/json');
+  if (options.body !== undefined) {
+    headers.set('Content-Type', 'application/json');
+  }
+
+  new Headers(options.init?.headers).forEach((value, key) => headers.set(key, value));
+
+  const query = search.toString();
+  const response = await (config.fetch ?? fetch)(config.baseUrl + path + (query ? '?' + query : ''), {
+    ...options.init,
+    method,
+    headers,
+    body: options.body === undefined ? undefined : JSON.stringify(options.body),
+  });
+
+  const text = await response.text();
+  const data = text ? JSON.parse(text) : undefined;
+  if (!response.ok) {
+    throw new ApiError(response.status, data);
+  }
+
+  return data as T;
+}
+`
+
+	sdkTypes = `// Code generated by bima. DO NOT EDIT.
+{{range .}}
+{{if .Fields}}export interface {{.Name}} {
+{{range .Fields}}  {{.}};
+{{end}}}
+{{else}}export type {{.Name}} = {{.Type}};
+{{end}}{{end}}`
+
+	sdkFile = `// Code generated by bima. DO NOT EDIT.
+import { request } from './client';
+{{if .Imports}}import type { {{.Imports}} } from './types';
+{{end}}{{range .Queries}}
+export interface {{.Name}} {
+{{range .Fields}}  {{.}};
+{{end}}}
+{{end}}{{range .Functions}}
+{{if .Summary}}/** {{.Summary}} */
+{{end}}{{if .Alias}}function {{.Alias}}{{else}}export function {{.Name}}{{end}}({{.Params}}): Promise<{{.Response}}> {
+  return request<{{.Response}}>('{{.Method}}', {{.Path}}{{if .Options}}, { {{.Options}} }{{end}});
+}
+{{if .Alias}}
+export { {{.Alias}} as {{.Name}} };
+{{end}}{{end}}`
+
+	sdkIndex = `// Code generated by bima. DO NOT EDIT.
+export * from './client';
+export * from './types';
+{{range .}}export * as {{.Namespace}} from './{{.File}}';
+{{end}}`
+
+	httpCollection = `@baseUrl = {{.BaseUrl}}
+@apiPrefix = {{.ApiPrefix}}
+{{if .Auth}}@token = token
+{{end}}{{range $k, $v := .Variables}}@{{$k}} = {{$v}}
+{{end}}{{range .Requests}}
+### {{.Name}}
+{{.Method}} {{.Url}}
+Accept: application/json
+{{if .Auth}}Authorization: Bearer {{"{{token}}"}}
+{{end}}{{if .Body}}Content-Type: application/json
+
+{{.Body}}
+{{end}}{{end}}`
+)
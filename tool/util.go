@@ -18,7 +18,6 @@ import (
 	"github.com/bimalabs/generators"
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
-	"github.com/gertd/go-pluralize"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/joho/godotenv"
@@ -32,6 +31,12 @@ import (
 type (
 	command string
 	util    string
+
+	connectionConfig struct {
+		Db struct {
+			Connections []string `json:"connections" yaml:"connections"`
+		} `json:"database" yaml:"database"`
+	}
 )
 
 func Pid() int {
@@ -335,12 +340,19 @@ func (u util) Run(file string) error {
 }
 
 func (u util) Genproto() error {
-	return command(`protoc -Iprotos -Ilibs --go_out=:protos/builds --go-grpc_out=:protos/builds protos/*.proto
+	err := command(`protoc -Iprotos -Ilibs --go_out=:protos/builds --go-grpc_out=:protos/builds protos/*.proto
 protoc -Iprotos -Ilibs --grpc-gateway_out=logtostderr=true:protos/builds protos/*.proto
 protoc -Iprotos -Ilibs --go_out=:protos/builds --go-grpc_out=:protos/builds libs/bima/*.proto
 protoc -Iprotos -Ilibs --grpc-gateway_out=logtostderr=true:protos/builds libs/bima/*.proto
 protoc -Iprotos -Ilibs --openapiv2_out=swaggers protos/*.proto
 `).run()
+	if err != nil {
+		return err
+	}
+
+	workDir, _ := os.Getwd()
+
+	return clients(workDir)
 }
 
 func (u util) toolchain() error {
@@ -352,7 +364,7 @@ google.golang.org/grpc/cmd/protoc-gen-go-grpc
 `).run()
 }
 
-func config(config *configs.Env, filePath string, ext string) {
+func config(config interface{}, filePath string, ext string) {
 	switch ext {
 	case ".env":
 		_ = godotenv.Load()
@@ -380,7 +392,16 @@ func config(config *configs.Env, filePath string, ext string) {
 	}
 }
 
-func parse(config *configs.Env) {
+func parse(config interface{}) {
+	switch value := config.(type) {
+	case *configs.Env:
+		parseEnv(value)
+	case *connectionConfig:
+		value.Db.Connections = strings.Split(os.Getenv("DB_CONNECTIONS"), ",")
+	}
+}
+
+func parseEnv(config *configs.Env) {
 	config.Debug, _ = strconv.ParseBool(os.Getenv("APP_DEBUG"))
 	config.HttpPort, _ = strconv.Atoi(os.Getenv("APP_PORT"))
 	config.RpcPort, _ = strconv.Atoi(os.Getenv("GRPC_PORT"))
@@ -401,11 +422,30 @@ func parse(config *configs.Env) {
 	config.CacheLifetime, _ = strconv.Atoi(os.Getenv("CACHE_LIFETIME"))
 }
 
-func NewGenerator(driver string, apiPrefix string) *generators.Factory {
+func connections(file string) []string {
+	setting := connectionConfig{}
+	config(&setting, file, filepath.Ext(file))
+
+	names := []string{}
+	for _, v := range setting.Db.Connections {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			names = append(names, v)
+		}
+	}
+
+	return names
+}
+
+func NewGenerator(driver string, apiPrefix string, config project, option ModuleOption, queries map[string]fieldQuery, encrypted map[string]bool) *generators.Factory {
+	if option.Prefix != "" {
+		apiPrefix = option.Prefix
+	}
+
 	return &generators.Factory{
 		Driver:     driver,
 		ApiPrefix:  apiPrefix,
-		Pluralizer: *pluralize.NewClient(),
+		Pluralizer: *config.pluralizer(),
 		Template:   generators.Template{},
 		Generators: []generators.Generator{
 			&generators.Dic{},
@@ -415,6 +455,17 @@ func NewGenerator(driver string, apiPrefix string) *generators.Factory {
 			&generators.Provider{},
 			&generators.Server{},
 			&generators.Swagger{},
+			&typeOverride{types: config.types()},
+			&apiPath{path: option.Path},
+			&connection{name: option.Connection},
+			&authorization{enabled: option.Authorization, path: option.Path},
+			&listing{queries: queries},
+			&event{enabled: option.Events},
+			&batch{enabled: option.Batch, tenant: option.Tenant, events: option.Events, path: option.Path},
+			&spreadsheet{enabled: option.Csv, tenant: option.Tenant, path: option.Path, types: config.types()},
+			&trash{enabled: option.SoftDelete, tenant: option.Tenant, connection: option.Connection, path: option.Path},
+			&tenancy{enabled: option.Tenant, path: option.Path},
+			&encryption{fields: encrypted},
 		},
 	}
 }